@@ -0,0 +1,383 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package artifactspec loads declarative artifact spec files describing the
+// content of an artifact to be pushed.
+package artifactspec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gopkg.in/yaml.v3"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+// Spec describes an artifact to be pushed.
+type Spec struct {
+	// ArtifactType is the artifact type of the manifest.
+	ArtifactType string
+	// Platform is the platform of the artifact.
+	Platform *ocispec.Platform
+	// Subject is the tag or digest of the subject artifact.
+	Subject string
+	// Tags are the tags to be applied to the pushed manifest.
+	Tags []string
+	// Config describes the manifest config.
+	Config *Config
+	// Annotations are the manifest annotations.
+	Annotations map[string]string
+	// Files are the files to be pushed as layers in order.
+	Files []File
+}
+
+// Config describes the manifest config.
+type Config struct {
+	Path        string
+	MediaType   string
+	Annotations map[string]string
+}
+
+// File describes a file matched by a file entry of the spec.
+type File struct {
+	// Path is the path of the file to be read.
+	Path string
+	// Name is the path of the file relative to the directory of the spec
+	// file, which is the name of the file in the artifact.
+	Name        string
+	MediaType   string
+	Annotations map[string]string
+}
+
+// Error is the error pointing at a position of the spec file.
+type Error struct {
+	File string
+	Line int
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.File, e.Err)
+}
+
+// Unwrap implements the errors.Wrapper interface.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrInvalidSpec is returned when the spec file is invalid.
+var ErrInvalidSpec = errors.New("invalid artifact spec")
+
+// Load loads and validates the spec file in YAML or JSON format. Relative file
+// paths and globs are resolved against the directory of the spec file.
+func Load(path string) (*Spec, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, content)
+}
+
+// Parse parses and validates the spec content read from the file named name.
+// Relative file paths are resolved against the directory of name.
+func Parse(name string, content []byte) (*Spec, error) {
+	p := &parser{
+		file: name,
+		dir:  filepath.Dir(name),
+	}
+	var doc yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	if err := decoder.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, p.errorf(nil, "empty spec")
+		}
+		return nil, &Error{File: name, Err: fmt.Errorf("%w: %v", ErrInvalidSpec, err)}
+	}
+	if len(doc.Content) == 0 {
+		return nil, p.errorf(nil, "empty spec")
+	}
+	return p.parseSpec(doc.Content[0])
+}
+
+type parser struct {
+	file string
+	// dir is the directory to resolve relative file paths against.
+	dir string
+}
+
+func (p *parser) errorf(node *yaml.Node, format string, a ...any) error {
+	err := &Error{
+		File: p.file,
+		Err:  fmt.Errorf("%w: %s", ErrInvalidSpec, fmt.Sprintf(format, a...)),
+	}
+	if node != nil {
+		err.Line = node.Line
+	}
+	return err
+}
+
+// fields returns the key and value nodes of a mapping node, rejecting unknown
+// and duplicated keys.
+func (p *parser) fields(node *yaml.Node, what string, known ...string) (map[string]*yaml.Node, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, p.errorf(node, "%s must be a mapping", what)
+	}
+	fields := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !slices.Contains(known, key.Value) {
+			return nil, p.errorf(key, "unknown field %q in %s, expecting one of: %s", key.Value, what, strings.Join(known, ", "))
+		}
+		if _, ok := fields[key.Value]; ok {
+			return nil, p.errorf(key, "duplicated field %q in %s", key.Value, what)
+		}
+		fields[key.Value] = value
+	}
+	return fields, nil
+}
+
+func (p *parser) string(node *yaml.Node, what string) (string, error) {
+	if node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+		return "", p.errorf(node, "%s must be a string", what)
+	}
+	return node.Value, nil
+}
+
+func (p *parser) annotations(node *yaml.Node, what string) (map[string]string, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, p.errorf(node, "%s must be a mapping of strings", what)
+	}
+	annotations := make(map[string]string, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value == "" {
+			return nil, p.errorf(key, "empty annotation key in %s", what)
+		}
+		if _, ok := annotations[key.Value]; ok {
+			return nil, p.errorf(key, "duplicated annotation key %q in %s", key.Value, what)
+		}
+		v, err := p.string(value, fmt.Sprintf("annotation %q", key.Value))
+		if err != nil {
+			return nil, err
+		}
+		annotations[key.Value] = v
+	}
+	return annotations, nil
+}
+
+func (p *parser) parseSpec(node *yaml.Node) (*Spec, error) {
+	fields, err := p.fields(node, "spec", "artifactType", "platform", "subject", "tags", "config", "annotations", "files")
+	if err != nil {
+		return nil, err
+	}
+	var spec Spec
+	if n, ok := fields["artifactType"]; ok {
+		if spec.ArtifactType, err = p.string(n, "artifactType"); err != nil {
+			return nil, err
+		}
+	}
+	if n, ok := fields["platform"]; ok {
+		platform, err := p.string(n, "platform")
+		if err != nil {
+			return nil, err
+		}
+		if spec.Platform, err = option.ParsePlatform(platform); err != nil {
+			return nil, p.errorf(n, "%v", err)
+		}
+	}
+	if n, ok := fields["subject"]; ok {
+		if spec.Subject, err = p.string(n, "subject"); err != nil {
+			return nil, err
+		}
+		if spec.Subject == "" {
+			return nil, p.errorf(n, "subject must not be empty")
+		}
+	}
+	if n, ok := fields["tags"]; ok {
+		if spec.Tags, err = p.parseTags(n); err != nil {
+			return nil, err
+		}
+	}
+	if n, ok := fields["config"]; ok {
+		if spec.Config, err = p.parseConfig(n); err != nil {
+			return nil, err
+		}
+		if spec.Platform != nil {
+			return nil, p.errorf(n, "config and platform cannot be both specified")
+		}
+	}
+	if n, ok := fields["annotations"]; ok {
+		if spec.Annotations, err = p.annotations(n, "annotations"); err != nil {
+			return nil, err
+		}
+	}
+	if n, ok := fields["files"]; ok {
+		if spec.Files, err = p.parseFiles(n); err != nil {
+			return nil, err
+		}
+	}
+	return &spec, nil
+}
+
+func (p *parser) parseTags(node *yaml.Node) ([]string, error) {
+	if node.Kind != yaml.SequenceNode {
+		return nil, p.errorf(node, "tags must be a list of strings")
+	}
+	tags := make([]string, 0, len(node.Content))
+	for _, n := range node.Content {
+		tag, err := p.string(n, "tag")
+		if err != nil {
+			return nil, err
+		}
+		if tag == "" || strings.ContainsAny(tag, ",@") {
+			return nil, p.errorf(n, "invalid tag %q", tag)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+func (p *parser) parseConfig(node *yaml.Node) (*Config, error) {
+	fields, err := p.fields(node, "config", "path", "mediaType", "annotations")
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	n, ok := fields["path"]
+	if !ok {
+		return nil, p.errorf(node, "missing path of config")
+	}
+	if config.Path, err = p.string(n, "path of config"); err != nil {
+		return nil, err
+	}
+	config.Path = p.resolve(config.Path)
+	if err := p.checkRegularFile(n, config.Path); err != nil {
+		return nil, err
+	}
+	if n, ok := fields["mediaType"]; ok {
+		if config.MediaType, err = p.string(n, "mediaType of config"); err != nil {
+			return nil, err
+		}
+	}
+	if n, ok := fields["annotations"]; ok {
+		if config.Annotations, err = p.annotations(n, "annotations of config"); err != nil {
+			return nil, err
+		}
+	}
+	return &config, nil
+}
+
+func (p *parser) parseFiles(node *yaml.Node) ([]File, error) {
+	if node.Kind != yaml.SequenceNode {
+		return nil, p.errorf(node, "files must be a list")
+	}
+	var files []File
+	seen := make(map[string]int)
+	for _, n := range node.Content {
+		fields, err := p.fields(n, "file", "path", "mediaType", "annotations")
+		if err != nil {
+			return nil, err
+		}
+		pathNode, ok := fields["path"]
+		if !ok {
+			return nil, p.errorf(n, "missing path of file")
+		}
+		pattern, err := p.string(pathNode, "path of file")
+		if err != nil {
+			return nil, err
+		}
+		var mediaType string
+		if mn, ok := fields["mediaType"]; ok {
+			if mediaType, err = p.string(mn, "mediaType of file"); err != nil {
+				return nil, err
+			}
+		}
+		var annotations map[string]string
+		if an, ok := fields["annotations"]; ok {
+			if annotations, err = p.annotations(an, "annotations of file"); err != nil {
+				return nil, err
+			}
+		}
+
+		matches, err := p.expand(pathNode, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range matches {
+			if line, ok := seen[path]; ok {
+				return nil, p.errorf(pathNode, "file %q is already included at line %d", path, line)
+			}
+			seen[path] = pathNode.Line
+			name := path
+			if !filepath.IsAbs(pattern) {
+				if name, err = filepath.Rel(p.dir, path); err != nil {
+					return nil, p.errorf(pathNode, "%v", err)
+				}
+			}
+			files = append(files, File{
+				Path:        path,
+				Name:        name,
+				MediaType:   mediaType,
+				Annotations: annotations,
+			})
+		}
+	}
+	return files, nil
+}
+
+// expand expands the glob pattern into file paths in lexical order.
+func (p *parser) expand(node *yaml.Node, pattern string) ([]string, error) {
+	if pattern == "" {
+		return nil, p.errorf(node, "empty path")
+	}
+	matches, err := filepath.Glob(p.resolve(pattern))
+	if err != nil {
+		return nil, p.errorf(node, "invalid glob pattern %q: %v", pattern, err)
+	}
+	if len(matches) == 0 {
+		return nil, p.errorf(node, "no file matches %q", pattern)
+	}
+	return matches, nil
+}
+
+// resolve resolves the relative path against the directory of the spec file.
+func (p *parser) resolve(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(p.dir, path)
+}
+
+func (p *parser) checkRegularFile(node *yaml.Node, path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return p.errorf(node, "%v", err)
+	}
+	if !fi.Mode().IsRegular() {
+		return p.errorf(node, "%q is not a regular file", path)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactspec
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func prepareFiles(t *testing.T, names ...string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	for _, name := range names {
+		if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(name), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	prepareFiles(t, "bin/linux-amd64", "bin/linux-arm64", "readme.md", "config.json")
+	content := `
+artifactType: application/vnd.example
+subject: v0
+tags: [v1, latest]
+annotations:
+  key: value
+config:
+  path: config.json
+  mediaType: application/vnd.example.config
+  annotations:
+    foo: bar
+files:
+  - path: "bin/*"
+    mediaType: application/x-binary
+    annotations:
+      kind: binary
+  - path: readme.md
+`
+	got, err := Parse("artifact.yaml", []byte(content))
+	if err != nil {
+		t.Fatal("Parse() error =", err)
+	}
+	want := &Spec{
+		ArtifactType: "application/vnd.example",
+		Subject:      "v0",
+		Tags:         []string{"v1", "latest"},
+		Annotations:  map[string]string{"key": "value"},
+		Config: &Config{
+			Path:        "config.json",
+			MediaType:   "application/vnd.example.config",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Files: []File{
+			{Path: filepath.Join("bin", "linux-amd64"), Name: filepath.Join("bin", "linux-amd64"), MediaType: "application/x-binary", Annotations: map[string]string{"kind": "binary"}},
+			{Path: filepath.Join("bin", "linux-arm64"), Name: filepath.Join("bin", "linux-arm64"), MediaType: "application/x-binary", Annotations: map[string]string{"kind": "binary"}},
+			{Path: "readme.md", Name: "readme.md"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParse_json(t *testing.T) {
+	prepareFiles(t, "hi.txt")
+	content := `{
+  "platform": "linux/arm/v7",
+  "files": [{"path": "hi.txt"}]
+}`
+	got, err := Parse("artifact.json", []byte(content))
+	if err != nil {
+		t.Fatal("Parse() error =", err)
+	}
+	want := &Spec{
+		Platform: &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+		Files:    []File{{Path: "hi.txt", Name: "hi.txt"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	prepareFiles(t, "hi.txt", "config.json")
+	tests := []struct {
+		name     string
+		content  string
+		wantLine int
+	}{
+		{"empty", "", 0},
+		{"not a mapping", "- foo", 1},
+		{"unknown field", "artifactType: foo\nlayers: []", 2},
+		{"duplicated field", "artifactType: foo\nartifactType: bar", 2},
+		{"invalid platform", "platform: linux/", 1},
+		{"empty subject", "subject: ''", 1},
+		{"invalid tag", "tags:\n  - v1\n  - a@b", 3},
+		{"tags not a list", "tags: v1", 1},
+		{"config without path", "config:\n  mediaType: foo", 2},
+		{"config not found", "config:\n  path: missing.json", 2},
+		{"config with platform", "platform: linux/amd64\nconfig:\n  path: config.json", 3},
+		{"annotation not a string", "annotations:\n  foo: [bar]", 2},
+		{"file without path", "files:\n  - mediaType: foo", 2},
+		{"file with unknown field", "files:\n  - path: hi.txt\n    type: foo", 3},
+		{"no file matched", "files:\n  - path: hi.txt\n  - path: '*.bin'", 3},
+		{"file included twice", "files:\n  - path: hi.txt\n  - path: '*.txt'", 3},
+		{"invalid glob", "files:\n  - path: '['", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("artifact.yaml", []byte(tt.content))
+			if !errors.Is(err, ErrInvalidSpec) {
+				t.Fatalf("Parse() error = %v, want %v", err, ErrInvalidSpec)
+			}
+			var specErr *Error
+			if !errors.As(err, &specErr) {
+				t.Fatalf("Parse() error = %v, want *Error", err)
+			}
+			if specErr.Line != tt.wantLine {
+				t.Errorf("Parse() error line = %d, want %d: %v", specErr.Line, tt.wantLine, err)
+			}
+		})
+	}
+}
+
+func TestLoad_relativeToSpecFile(t *testing.T) {
+	prepareFiles(t, "dir/bin/app", "dir/config.json", "bin/other")
+	content := `
+config:
+  path: config.json
+files:
+  - path: "bin/*"
+`
+	if err := os.WriteFile(filepath.Join("dir", "artifact.yaml"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(filepath.Join("dir", "artifact.yaml"))
+	if err != nil {
+		t.Fatal("Load() error =", err)
+	}
+	want := &Spec{
+		Config: &Config{Path: filepath.Join("dir", "config.json")},
+		Files: []File{
+			{Path: filepath.Join("dir", "bin", "app"), Name: filepath.Join("bin", "app")},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoad_notFound(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if _, err := Load("missing.yaml"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Load() error = %v, want %v", err, os.ErrNotExist)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/content"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/descriptor"
+	"oras.land/oras/cmd/oras/internal/display/metadata/graph"
 	"oras.land/oras/cmd/oras/internal/display/metadata/json"
 	"oras.land/oras/cmd/oras/internal/display/metadata/table"
 	"oras.land/oras/cmd/oras/internal/display/metadata/template"
@@ -123,6 +124,43 @@ func NewDiscoverHandler(out io.Writer, format option.Format, path string, rawRef
 		handler = json.NewDiscoverHandler(out, desc, path)
 	case option.FormatTypeGoTemplate.Name:
 		handler = template.NewDiscoverHandler(out, desc, path, format.Template)
+	case option.FormatTypeDOT.Name:
+		handler = graph.NewDOTDiscoverHandler(out, path, desc)
+	case option.FormatTypeMermaid.Name:
+		handler = graph.NewMermaidDiscoverHandler(out, path, desc)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewDiscoverAncestorsHandler returns a metadata handler for discovering the
+// subject chain.
+func NewDiscoverAncestorsHandler(out io.Writer, format option.Format, path string, tty *os.File) (metadata.DiscoverAncestorsHandler, error) {
+	var handler metadata.DiscoverAncestorsHandler
+	switch format.Type {
+	case option.FormatTypeTree.Name:
+		handler = tree.NewDiscoverAncestorsHandler(out, path, tty)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewDiscoverAncestorsHandler(out, path)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewDiscoverAncestorsHandler(out, path, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewInspectHandler returns an inspect handler.
+func NewInspectHandler(out io.Writer, format option.Format, path string, root ocispec.Descriptor, tty *os.File) (metadata.InspectHandler, error) {
+	var handler metadata.InspectHandler
+	switch format.Type {
+	case option.FormatTypeTree.Name:
+		handler = tree.NewInspectHandler(out, path, root, tty)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewInspectHandler(out, path, root)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewInspectHandler(out, path, root, format.Template)
 	default:
 		return nil, errors.UnsupportedFormatTypeError(format.Type)
 	}
@@ -169,12 +207,13 @@ func NewTagHandler(printer *output.Printer, target option.Target) metadata.TagHa
 	return text.NewTagHandler(printer, target)
 }
 
-// NewManifestPushHandler returns a manifest push handler.
-func NewManifestPushHandler(printer *output.Printer, outputDescriptor bool, pretty bool, desc ocispec.Descriptor, target *option.Target) (status.ManifestPushHandler, metadata.ManifestPushHandler) {
+// NewManifestPushHandler returns a manifest push handler, along with the
+// handler of the findings if the manifest is validated before pushing.
+func NewManifestPushHandler(printer *output.Printer, outputDescriptor bool, pretty bool, desc ocispec.Descriptor, target *option.Target) (status.ManifestPushHandler, metadata.ManifestPushHandler, metadata.ManifestValidateHandler) {
 	if outputDescriptor {
-		return status.NewDiscardHandler(), metadata.NewDiscardHandler()
+		return status.NewDiscardHandler(), metadata.NewDiscardHandler(), metadata.NewDiscardHandler()
 	}
-	return status.NewTextManifestPushHandler(printer, desc), text.NewManifestPushHandler(printer, target)
+	return status.NewTextManifestPushHandler(printer, desc), text.NewManifestPushHandler(printer, target), text.NewManifestValidateHandler(printer)
 }
 
 // NewManifestDeleteHandler returns a manifest delete handler.
@@ -267,6 +306,50 @@ func NewBlobDeleteHandler(printer *output.Printer, target *option.Target) metada
 	return text.NewBlobDeleteHandler(printer, target)
 }
 
+// NewManifestDiffHandler returns a manifest diff handler.
+func NewManifestDiffHandler(printer *output.Printer, format option.Format) (metadata.ManifestDiffHandler, error) {
+	var handler metadata.ManifestDiffHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewManifestDiffHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewManifestDiffHandler(printer)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewManifestValidateHandler returns a manifest validate handler.
+func NewManifestValidateHandler(printer *output.Printer, format option.Format) (metadata.ManifestValidateHandler, error) {
+	var handler metadata.ManifestValidateHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewManifestValidateHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewManifestValidateHandler(printer)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewVerifyHandler returns a verify handler.
+func NewVerifyHandler(printer *output.Printer, format option.Format, target *option.Target, subject ocispec.Descriptor) (metadata.VerifyHandler, error) {
+	var handler metadata.VerifyHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewVerifyHandler(printer, target.GetDisplayReference(), subject)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewVerifyHandler(printer, target.GetDigestReference(subject), subject)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewVerifyHandler(printer, format.Template, target.GetDigestReference(subject), subject)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
 // NewRepoTagsHandler returns a repo tags handler.
 func NewRepoTagsHandler(out io.Writer, format option.Format) (metadata.RepoTagsHandler, error) {
 	var handler metadata.RepoTagsHandler
@@ -298,3 +381,67 @@ func NewRepoListHandler(out io.Writer, format option.Format, registry, namespace
 	}
 	return handler, nil
 }
+
+// NewCacheListHandler returns a metadata handler for cache ls command.
+func NewCacheListHandler(out io.Writer, format option.Format, root string) (metadata.CacheListHandler, error) {
+	var handler metadata.CacheListHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewCacheListHandler(out)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewCacheListHandler(out, root)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewCacheListHandler(out, format.Template, root)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewCacheUsageHandler returns a metadata handler for cache du command.
+func NewCacheUsageHandler(out io.Writer, format option.Format, root string) (metadata.CacheListHandler, error) {
+	var handler metadata.CacheListHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewCacheUsageHandler(out, root)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewCacheUsageHandler(out, root)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewCacheUsageHandler(out, format.Template, root)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewCachePruneHandler returns a metadata handler for cache prune command.
+func NewCachePruneHandler(out io.Writer, format option.Format, root string, dryRun bool) (metadata.CachePruneHandler, error) {
+	var handler metadata.CachePruneHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewCachePruneHandler(out, dryRun)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewCachePruneHandler(out, root, dryRun)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewCachePruneHandler(out, format.Template, root, dryRun)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewCacheClearHandler returns a metadata handler for cache clear command.
+func NewCacheClearHandler(out io.Writer, format option.Format) (metadata.CacheClearHandler, error) {
+	var handler metadata.CacheClearHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewCacheClearHandler(out)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewCacheClearHandler(out)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewCacheClearHandler(out, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
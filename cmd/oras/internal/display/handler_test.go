@@ -20,6 +20,8 @@ import (
 	"reflect"
 	"testing"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
 	"oras.land/oras/internal/testutils"
 
 	"oras.land/oras/cmd/oras/internal/display/metadata/text"
@@ -103,6 +105,36 @@ func TestNewRepoTagsHandler(t *testing.T) {
 	}
 }
 
+func TestNewVerifyHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      option.Format
+		expectError bool
+	}{
+		{"text format", option.Format{Type: option.FormatTypeText.Name}, false},
+		{"JSON format", option.Format{Type: option.FormatTypeJSON.Name}, false},
+		{"Go template", option.Format{Type: option.FormatTypeGoTemplate.Name, Template: "{{.verified}}"}, false},
+		{"unsupported", option.Format{Type: "unsupported"}, true},
+	}
+	printer := output.NewPrinter(os.Stdout, os.Stderr)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewVerifyHandler(printer, tt.format, &option.Target{}, ocispec.Descriptor{})
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError {
+				if err != nil {
+					t.Errorf("error = %v, want nil", err)
+				}
+				if handler == nil {
+					t.Error("returned nil handler")
+				}
+			}
+		})
+	}
+}
+
 func TestNewRepoListHandler(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,196 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/internal/descriptor"
+)
+
+// edge is a directed edge between two nodes identified by their indexes.
+type edge struct {
+	from    int
+	to      int
+	subject bool
+}
+
+// discoverHandler handles graph metadata output for discover events.
+type discoverHandler struct {
+	out    io.Writer
+	path   string
+	nodes  []ocispec.Descriptor
+	ids    map[digest.Digest]int
+	edges  []edge
+	seen   map[edge]bool
+	render func() error
+}
+
+// NewDOTDiscoverHandler creates a new handler for discover events, rendering
+// the artifact graph in Graphviz DOT language.
+func NewDOTDiscoverHandler(out io.Writer, path string, root ocispec.Descriptor) metadata.DiscoverGraphHandler {
+	h := newDiscoverHandler(out, path, root)
+	h.render = h.renderDOT
+	return h
+}
+
+// NewMermaidDiscoverHandler creates a new handler for discover events,
+// rendering the artifact graph as a Mermaid flowchart.
+func NewMermaidDiscoverHandler(out io.Writer, path string, root ocispec.Descriptor) metadata.DiscoverGraphHandler {
+	h := newDiscoverHandler(out, path, root)
+	h.render = h.renderMermaid
+	return h
+}
+
+func newDiscoverHandler(out io.Writer, path string, root ocispec.Descriptor) *discoverHandler {
+	return &discoverHandler{
+		out:   out,
+		path:  path,
+		nodes: []ocispec.Descriptor{root},
+		ids: map[digest.Digest]int{
+			root.Digest: 0,
+		},
+		seen: make(map[edge]bool),
+	}
+}
+
+// OnDiscovered implements metadata.DiscoverHandler.
+func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) error {
+	to, ok := h.ids[subject.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected subject descriptor: %v", subject)
+	}
+	h.addEdge(edge{
+		from:    h.addNode(referrer),
+		to:      to,
+		subject: true,
+	})
+	return nil
+}
+
+// OnManifestDiscovered implements metadata.DiscoverManifestHandler.
+func (h *discoverHandler) OnManifestDiscovered(manifest, index ocispec.Descriptor) error {
+	return h.OnContentDiscovered(manifest, index)
+}
+
+// OnContentDiscovered implements metadata.DiscoverGraphHandler.
+func (h *discoverHandler) OnContentDiscovered(node, parent ocispec.Descriptor) error {
+	from, ok := h.ids[parent.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected parent descriptor: %v", parent)
+	}
+	h.addEdge(edge{
+		from: from,
+		to:   h.addNode(node),
+	})
+	return nil
+}
+
+// Render implements metadata.DiscoverHandler.
+func (h *discoverHandler) Render() error {
+	return h.render()
+}
+
+// addNode adds the node if not added and returns its index.
+func (h *discoverHandler) addNode(node ocispec.Descriptor) int {
+	if id, ok := h.ids[node.Digest]; ok {
+		return id
+	}
+	id := len(h.nodes)
+	h.nodes = append(h.nodes, node)
+	h.ids[node.Digest] = id
+	return id
+}
+
+// addEdge adds the edge if not added.
+func (h *discoverHandler) addEdge(e edge) {
+	if h.seen[e] {
+		return
+	}
+	h.seen[e] = true
+	h.edges = append(h.edges, e)
+}
+
+// label returns the label lines of the node, including the artifact type,
+// the media type, the digest and the size.
+func (h *discoverHandler) label(id int) []string {
+	node := h.nodes[id]
+	var lines []string
+	if id == 0 {
+		lines = append(lines, h.path)
+	}
+	if node.ArtifactType != "" {
+		lines = append(lines, node.ArtifactType)
+	}
+	size := humanize.ToBytes(node.Size)
+	return append(lines,
+		node.MediaType,
+		descriptor.ShortDigest(node),
+		fmt.Sprintf("%g %s", size.Size, size.Unit),
+	)
+}
+
+// renderDOT renders the graph in Graphviz DOT language.
+func (h *discoverHandler) renderDOT() error {
+	var sb strings.Builder
+	sb.WriteString("digraph {\n")
+	sb.WriteString("  node [shape=box];\n")
+	for id, node := range h.nodes {
+		_, _ = fmt.Fprintf(&sb, "  %q [label=%s];\n", node.Digest, strconv.Quote(strings.Join(h.label(id), "\n")))
+	}
+	for _, e := range h.edges {
+		_, _ = fmt.Fprintf(&sb, "  %q -> %q", h.nodes[e.from].Digest, h.nodes[e.to].Digest)
+		if e.subject {
+			sb.WriteString(` [label="subject", style=dashed]`)
+		}
+		sb.WriteString(";\n")
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(h.out, sb.String())
+	return err
+}
+
+// mermaidEscaper escapes the characters not allowed in Mermaid labels.
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+
+// renderMermaid renders the graph as a Mermaid flowchart.
+func (h *discoverHandler) renderMermaid() error {
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+	for id := range h.nodes {
+		lines := h.label(id)
+		for i, line := range lines {
+			lines[i] = mermaidEscaper.Replace(line)
+		}
+		_, _ = fmt.Fprintf(&sb, "  n%d[\"%s\"]\n", id, strings.Join(lines, "<br/>"))
+	}
+	for _, e := range h.edges {
+		if e.subject {
+			_, _ = fmt.Fprintf(&sb, "  n%d -.->|subject| n%d\n", e.from, e.to)
+		} else {
+			_, _ = fmt.Fprintf(&sb, "  n%d --> n%d\n", e.from, e.to)
+		}
+	}
+	_, err := io.WriteString(h.out, sb.String())
+	return err
+}
@@ -0,0 +1,103 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+)
+
+var (
+	subjectDesc = ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2",
+		Size:      529,
+	}
+	referrerDesc = ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		Digest:       "sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d",
+		Size:         2048,
+		ArtifactType: "test/sbom.file",
+	}
+	layerDesc = ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Size:      3,
+	}
+)
+
+func discover(t *testing.T, h metadata.DiscoverGraphHandler) {
+	t.Helper()
+	if err := h.OnDiscovered(referrerDesc, subjectDesc); err != nil {
+		t.Fatal("OnDiscovered() error =", err)
+	}
+	// shared content is rendered once
+	for _, parent := range []ocispec.Descriptor{subjectDesc, referrerDesc, referrerDesc} {
+		if err := h.OnContentDiscovered(layerDesc, parent); err != nil {
+			t.Fatal("OnContentDiscovered() error =", err)
+		}
+	}
+	if err := h.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+}
+
+func TestDOTDiscoverHandler(t *testing.T) {
+	var buf bytes.Buffer
+	discover(t, NewDOTDiscoverHandler(&buf, "localhost:5000/test", subjectDesc))
+	want := `digraph {
+  node [shape=box];
+  "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2" [label="localhost:5000/test\napplication/vnd.oci.image.manifest.v1+json\n9d16f5505246\n529 B"];
+  "sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d" [label="test/sbom.file\napplication/vnd.oci.image.manifest.v1+json\ne2c6633a7998\n2 KB"];
+  "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae" [label="application/vnd.oci.image.layer.v1.tar\n2c26b46b68ff\n3 B"];
+  "sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d" -> "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2" [label="subject", style=dashed];
+  "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2" -> "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae";
+  "sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d" -> "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae";
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %s, want %s", got, want)
+	}
+}
+
+func TestMermaidDiscoverHandler(t *testing.T) {
+	var buf bytes.Buffer
+	discover(t, NewMermaidDiscoverHandler(&buf, "localhost:5000/test", subjectDesc))
+	want := `flowchart TD
+  n0["localhost:5000/test<br/>application/vnd.oci.image.manifest.v1+json<br/>9d16f5505246<br/>529 B"]
+  n1["test/sbom.file<br/>application/vnd.oci.image.manifest.v1+json<br/>e2c6633a7998<br/>2 KB"]
+  n2["application/vnd.oci.image.layer.v1.tar<br/>2c26b46b68ff<br/>3 B"]
+  n1 -.->|subject| n0
+  n0 --> n2
+  n1 --> n2
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %s, want %s", got, want)
+	}
+}
+
+func TestDiscoverHandler_unexpected(t *testing.T) {
+	h := NewDOTDiscoverHandler(&bytes.Buffer{}, "localhost:5000/test", subjectDesc)
+	if err := h.OnDiscovered(subjectDesc, referrerDesc); err == nil {
+		t.Error("OnDiscovered() error = nil, want error")
+	}
+	if err := h.OnContentDiscovered(layerDesc, referrerDesc); err == nil {
+		t.Error("OnContentDiscovered() error = nil, want error")
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/manifest"
 	"oras.land/oras/cmd/oras/internal/option"
 )
 
@@ -50,18 +51,100 @@ type DiscoverHandler interface {
 	OnDiscovered(referrer, subject ocispec.Descriptor) error
 }
 
+// DiscoverAncestorsHandler handles metadata output for discovering the subject
+// chain of an artifact.
+type DiscoverAncestorsHandler interface {
+	Renderer
+
+	// OnAncestorDiscovered is called after an artifact in the subject chain is
+	// discovered, starting from the given artifact up to the root. tags are
+	// the tags resolving to the artifact.
+	OnAncestorDiscovered(node ocispec.Descriptor, tags []string) error
+}
+
+// DiscoverManifestHandler handles metadata output for discover events including
+// the child manifests of an index.
+type DiscoverManifestHandler interface {
+	DiscoverHandler
+
+	// OnManifestDiscovered is called after a child manifest of an index is
+	// discovered.
+	OnManifestDiscovered(manifest, index ocispec.Descriptor) error
+}
+
+// DiscoverGraphHandler handles metadata output for discover events rendered as
+// an artifact graph.
+type DiscoverGraphHandler interface {
+	DiscoverManifestHandler
+
+	// OnContentDiscovered is called after a child manifest, config or layer of
+	// a discovered manifest is discovered.
+	OnContentDiscovered(node, parent ocispec.Descriptor) error
+}
+
+// InspectHandler handles metadata output for inspect events.
+type InspectHandler interface {
+	Renderer
+
+	// OnTagsFound is called with the tags resolving to the inspected artifact.
+	OnTagsFound(tags []string) error
+	// OnManifestInspected is called after a child manifest of an index is
+	// inspected.
+	OnManifestInspected(manifest, index ocispec.Descriptor) error
+	// OnConfigInspected is called after the config of a manifest is
+	// inspected. image is the parsed image config, or nil if the config is
+	// not an image config.
+	OnConfigInspected(config ocispec.Descriptor, image *ocispec.Image, manifest ocispec.Descriptor) error
+	// OnLayerInspected is called for each layer of an inspected manifest.
+	OnLayerInspected(layer, manifest ocispec.Descriptor) error
+	// OnReferrerDiscovered is called after a referrer of the inspected
+	// artifact or of its referrers is discovered.
+	OnReferrerDiscovered(referrer, subject ocispec.Descriptor) error
+}
+
 // ManifestFetchHandler handles metadata output for manifest fetch events.
 type ManifestFetchHandler interface {
 	// OnFetched is called after the manifest content is fetched.
 	OnFetched(path string, desc ocispec.Descriptor, content []byte) error
 }
 
+// ManifestDiffHandler handles metadata output for manifest diff events.
+type ManifestDiffHandler interface {
+	// OnCompared is called after the manifest from the source target is
+	// compared with the manifest from the destination target.
+	OnCompared(target *option.BinaryTarget, from, to ocispec.Descriptor, diff *manifest.Diff) error
+}
+
+// ManifestValidateHandler handles metadata output for manifest validate events.
+type ManifestValidateHandler interface {
+	// OnValidated is called after the manifest from the target is validated.
+	OnValidated(target *option.Target, desc ocispec.Descriptor, findings []manifest.Finding) error
+}
+
+// VerifyHandler handles metadata output for verify events.
+type VerifyHandler interface {
+	Renderer
+
+	// OnSignatureVerified is called after a signature of the artifact is
+	// verified, where err is the verification error if the signature is
+	// invalid.
+	OnSignatureVerified(sig ocispec.Descriptor, err error) error
+}
+
 // PullHandler handles metadata output for pull events.
 type PullHandler interface {
 	Renderer
 
 	// OnLayerSkipped is called when a layer is skipped.
 	OnLayerSkipped(ocispec.Descriptor) error
+	// OnFileSkipped is called when a file is filtered out.
+	OnFileSkipped(name string, desc ocispec.Descriptor, descPath string) error
+	// OnPlatformPulling is called before pulling the files of the
+	// platform-specific manifest desc of a multi-platform artifact.
+	OnPlatformPulling(desc ocispec.Descriptor) error
+	// OnReferrerPulling is called before pulling the files of the referrer
+	// desc of the artifact.
+	OnReferrerPulling(desc ocispec.Descriptor) error
 	// OnFilePulled is called after a file is pulled.
 	OnFilePulled(name string, outputDir string, desc ocispec.Descriptor, descPath string) error
 	// OnPulled is called when a pull operation completes.
@@ -161,6 +244,30 @@ type RepoTagsHandler interface {
 	OnTagListed(tag string) error
 }
 
+// CacheListHandler handles metadata output for cache ls and cache du
+// commands.
+type CacheListHandler interface {
+	Renderer
+
+	// OnBlobListed is called for each blob in the cache.
+	OnBlobListed(desc ocispec.Descriptor, lastAccess time.Time) error
+}
+
+// CachePruneHandler handles metadata output for cache prune command.
+type CachePruneHandler interface {
+	Renderer
+
+	// OnBlobPruned is called for each blob removed from the cache, or to be
+	// removed in a dry run.
+	OnBlobPruned(desc ocispec.Descriptor, lastAccess time.Time) error
+}
+
+// CacheClearHandler handles metadata output for cache clear command.
+type CacheClearHandler interface {
+	// OnCleared is called after the cache at root is cleared.
+	OnCleared(root string) error
+}
+
 // RepoListHandler handles metadata output for repo ls command.
 type RepoListHandler interface {
 	Renderer
@@ -0,0 +1,118 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// cacheListHandler handles json metadata output for cache ls command.
+type cacheListHandler struct {
+	out   io.Writer
+	model *model.CacheBlobs
+}
+
+// NewCacheListHandler creates a new handler for cache ls events.
+func NewCacheListHandler(out io.Writer, root string) metadata.CacheListHandler {
+	return &cacheListHandler{
+		out:   out,
+		model: model.NewCacheBlobs(root),
+	}
+}
+
+// OnBlobListed implements metadata.CacheListHandler.
+func (h *cacheListHandler) OnBlobListed(desc ocispec.Descriptor, lastAccess time.Time) error {
+	h.model.AddBlob(desc.Digest.String(), desc.Size, lastAccess)
+	return nil
+}
+
+// Render implements metadata.CacheListHandler.
+func (h *cacheListHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
+
+// cacheUsageHandler handles json metadata output for cache du command.
+type cacheUsageHandler struct {
+	out   io.Writer
+	model *model.CacheUsage
+}
+
+// NewCacheUsageHandler creates a new handler for cache du events.
+func NewCacheUsageHandler(out io.Writer, root string) metadata.CacheListHandler {
+	return &cacheUsageHandler{
+		out:   out,
+		model: model.NewCacheUsage(root),
+	}
+}
+
+// OnBlobListed implements metadata.CacheListHandler.
+func (h *cacheUsageHandler) OnBlobListed(desc ocispec.Descriptor, _ time.Time) error {
+	h.model.AddBlob(desc.Size)
+	return nil
+}
+
+// Render implements metadata.CacheListHandler.
+func (h *cacheUsageHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
+
+// cachePruneHandler handles json metadata output for cache prune command.
+type cachePruneHandler struct {
+	out   io.Writer
+	model *model.CachePrune
+}
+
+// NewCachePruneHandler creates a new handler for cache prune events.
+func NewCachePruneHandler(out io.Writer, root string, dryRun bool) metadata.CachePruneHandler {
+	return &cachePruneHandler{
+		out:   out,
+		model: model.NewCachePrune(root, dryRun),
+	}
+}
+
+// OnBlobPruned implements metadata.CachePruneHandler.
+func (h *cachePruneHandler) OnBlobPruned(desc ocispec.Descriptor, lastAccess time.Time) error {
+	h.model.AddBlob(desc.Digest.String(), desc.Size, lastAccess)
+	return nil
+}
+
+// Render implements metadata.CachePruneHandler.
+func (h *cachePruneHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
+
+// cacheClearHandler handles json metadata output for cache clear command.
+type cacheClearHandler struct {
+	out io.Writer
+}
+
+// NewCacheClearHandler creates a new handler for cache clear events.
+func NewCacheClearHandler(out io.Writer) metadata.CacheClearHandler {
+	return &cacheClearHandler{
+		out: out,
+	}
+}
+
+// OnCleared implements metadata.CacheClearHandler.
+func (h *cacheClearHandler) OnCleared(root string) error {
+	return output.PrintPrettyJSON(h.out, model.CacheClear{Root: root})
+}
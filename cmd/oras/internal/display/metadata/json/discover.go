@@ -32,7 +32,7 @@ type discoverHandler struct {
 }
 
 // NewDiscoverHandler creates a new handler for discover events.
-func NewDiscoverHandler(out io.Writer, subject ocispec.Descriptor, path string) metadata.DiscoverHandler {
+func NewDiscoverHandler(out io.Writer, subject ocispec.Descriptor, path string) metadata.DiscoverManifestHandler {
 	return &discoverHandler{
 		out:   out,
 		path:  path,
@@ -45,7 +45,41 @@ func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) err
 	return h.model.AddReferrer(referrer, subject)
 }
 
+// OnManifestDiscovered implements metadata.DiscoverManifestHandler.
+func (h *discoverHandler) OnManifestDiscovered(manifest, index ocispec.Descriptor) error {
+	return h.model.AddManifest(manifest, index)
+}
+
 // Render implements metadata.DiscoverHandler.
 func (h *discoverHandler) Render() error {
 	return output.PrintPrettyJSON(h.out, h.model.Root)
 }
+
+// discoverAncestorsHandler handles json metadata output for discovering the
+// subject chain.
+type discoverAncestorsHandler struct {
+	out       io.Writer
+	path      string
+	ancestors []model.Ancestor
+}
+
+// NewDiscoverAncestorsHandler creates a new handler for discovering the
+// subject chain.
+func NewDiscoverAncestorsHandler(out io.Writer, path string) metadata.DiscoverAncestorsHandler {
+	return &discoverAncestorsHandler{
+		out:       out,
+		path:      path,
+		ancestors: []model.Ancestor{},
+	}
+}
+
+// OnAncestorDiscovered implements metadata.DiscoverAncestorsHandler.
+func (h *discoverAncestorsHandler) OnAncestorDiscovered(node ocispec.Descriptor, tags []string) error {
+	h.ancestors = append(h.ancestors, model.NewAncestor(h.path, node, tags))
+	return nil
+}
+
+// Render implements metadata.DiscoverAncestorsHandler.
+func (h *discoverAncestorsHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.ancestors)
+}
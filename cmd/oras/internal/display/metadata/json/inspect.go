@@ -0,0 +1,70 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// inspectHandler handles JSON metadata output for inspect events.
+type inspectHandler struct {
+	out   io.Writer
+	model *model.Inspect
+}
+
+// NewInspectHandler creates a new handler for inspect events.
+func NewInspectHandler(out io.Writer, path string, root ocispec.Descriptor) metadata.InspectHandler {
+	return &inspectHandler{
+		out:   out,
+		model: model.NewInspect(path, root),
+	}
+}
+
+// OnTagsFound implements metadata.InspectHandler.
+func (h *inspectHandler) OnTagsFound(tags []string) error {
+	h.model.AddTags(tags)
+	return nil
+}
+
+// OnManifestInspected implements metadata.InspectHandler.
+func (h *inspectHandler) OnManifestInspected(manifest, index ocispec.Descriptor) error {
+	return h.model.AddManifest(manifest, index)
+}
+
+// OnConfigInspected implements metadata.InspectHandler.
+func (h *inspectHandler) OnConfigInspected(config ocispec.Descriptor, image *ocispec.Image, manifest ocispec.Descriptor) error {
+	return h.model.SetConfig(config, image, manifest)
+}
+
+// OnLayerInspected implements metadata.InspectHandler.
+func (h *inspectHandler) OnLayerInspected(layer, manifest ocispec.Descriptor) error {
+	return h.model.AddLayer(layer, manifest)
+}
+
+// OnReferrerDiscovered implements metadata.InspectHandler.
+func (h *inspectHandler) OnReferrerDiscovered(referrer, subject ocispec.Descriptor) error {
+	return h.model.AddReferrer(referrer, subject)
+}
+
+// Render implements metadata.InspectHandler.
+func (h *inspectHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
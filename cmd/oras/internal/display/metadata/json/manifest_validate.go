@@ -0,0 +1,46 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// manifestValidateHandler handles JSON metadata output for manifest validate
+// events.
+type manifestValidateHandler struct {
+	out io.Writer
+}
+
+// NewManifestValidateHandler creates a new handler for manifest validate
+// events.
+func NewManifestValidateHandler(out io.Writer) metadata.ManifestValidateHandler {
+	return &manifestValidateHandler{
+		out: out,
+	}
+}
+
+// OnValidated implements metadata.ManifestValidateHandler.
+func (h *manifestValidateHandler) OnValidated(target *option.Target, desc ocispec.Descriptor, findings []manifest.Finding) error {
+	return output.PrintPrettyJSON(h.out, model.NewManifestValidate(target.GetDigestReference(desc), desc, findings))
+}
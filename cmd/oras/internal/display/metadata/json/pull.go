@@ -41,6 +41,24 @@ func NewPullHandler(out io.Writer, path string) metadata.PullHandler {
 	}
 }
 
+// OnFileSkipped implements metadata.PullHandler.
+func (ph *PullHandler) OnFileSkipped(_ string, desc ocispec.Descriptor, descPath string) error {
+	ph.pulled.AddSkipped(desc, descPath)
+	return nil
+}
+
+// OnPlatformPulling implements metadata.PullHandler.
+func (ph *PullHandler) OnPlatformPulling(desc ocispec.Descriptor) error {
+	ph.pulled.AddPlatform(desc, ph.path)
+	return nil
+}
+
+// OnReferrerPulling implements metadata.PullHandler.
+func (ph *PullHandler) OnReferrerPulling(desc ocispec.Descriptor) error {
+	ph.pulled.AddReferrer(desc, ph.path)
+	return nil
+}
+
 // OnLayerSkipped implements metadata.PullHandler.
 func (ph *PullHandler) OnLayerSkipped(ocispec.Descriptor) error {
 	return nil
@@ -58,5 +76,5 @@ func (ph *PullHandler) OnPulled(_ *option.Target, desc ocispec.Descriptor) {
 
 // Render implements metadata.PullHandler.
 func (ph *PullHandler) Render() error {
-	return output.PrintPrettyJSON(ph.out, model.NewPull(ph.path+"@"+ph.root.Digest.String(), ph.pulled.Files()))
+	return output.PrintPrettyJSON(ph.out, model.NewPull(ph.path+"@"+ph.root.Digest.String(), ph.pulled.Files(), ph.pulled.Skipped(), ph.pulled.Platforms(), ph.pulled.Referrers()))
 }
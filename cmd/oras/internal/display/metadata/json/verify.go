@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// verifyHandler handles JSON metadata output for verify events.
+type verifyHandler struct {
+	out   io.Writer
+	model *model.Verify
+}
+
+// NewVerifyHandler creates a new handler for verify events.
+func NewVerifyHandler(out io.Writer, reference string, subject ocispec.Descriptor) metadata.VerifyHandler {
+	return &verifyHandler{
+		out:   out,
+		model: model.NewVerify(reference, subject),
+	}
+}
+
+// OnSignatureVerified implements metadata.VerifyHandler.
+func (h *verifyHandler) OnSignatureVerified(sig ocispec.Descriptor, err error) error {
+	h.model.AddSignature(sig, err)
+	return nil
+}
+
+// Render implements metadata.VerifyHandler.
+func (h *verifyHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "time"
+
+// CacheBlob contains metadata of a blob in the cache.
+type CacheBlob struct {
+	Digest     string    `json:"digest"`
+	Size       int64     `json:"size"`
+	LastAccess time.Time `json:"lastAccess"`
+}
+
+// CacheBlobs contains metadata formatted by oras cache ls.
+type CacheBlobs struct {
+	Root  string      `json:"root"`
+	Blobs []CacheBlob `json:"blobs"`
+}
+
+// NewCacheBlobs creates a new CacheBlobs model.
+func NewCacheBlobs(root string) *CacheBlobs {
+	return &CacheBlobs{
+		Root:  root,
+		Blobs: []CacheBlob{},
+	}
+}
+
+// AddBlob adds a blob to the metadata.
+func (c *CacheBlobs) AddBlob(digest string, size int64, lastAccess time.Time) {
+	c.Blobs = append(c.Blobs, CacheBlob{
+		Digest:     digest,
+		Size:       size,
+		LastAccess: lastAccess,
+	})
+}
+
+// CacheUsage contains metadata formatted by oras cache du.
+type CacheUsage struct {
+	Root  string `json:"root"`
+	Count int    `json:"count"`
+	Size  int64  `json:"size"`
+}
+
+// NewCacheUsage creates a new CacheUsage model.
+func NewCacheUsage(root string) *CacheUsage {
+	return &CacheUsage{
+		Root: root,
+	}
+}
+
+// AddBlob adds the size of a blob to the usage.
+func (c *CacheUsage) AddBlob(size int64) {
+	c.Count++
+	c.Size += size
+}
+
+// CachePrune contains metadata formatted by oras cache prune.
+type CachePrune struct {
+	Root   string      `json:"root"`
+	DryRun bool        `json:"dryRun"`
+	Blobs  []CacheBlob `json:"blobs"`
+	Size   int64       `json:"size"`
+}
+
+// NewCachePrune creates a new CachePrune model.
+func NewCachePrune(root string, dryRun bool) *CachePrune {
+	return &CachePrune{
+		Root:   root,
+		DryRun: dryRun,
+		Blobs:  []CacheBlob{},
+	}
+}
+
+// AddBlob adds a pruned blob to the metadata.
+func (c *CachePrune) AddBlob(digest string, size int64, lastAccess time.Time) {
+	c.Blobs = append(c.Blobs, CacheBlob{
+		Digest:     digest,
+		Size:       size,
+		LastAccess: lastAccess,
+	})
+	c.Size += size
+}
+
+// CacheClear contains metadata formatted by oras cache clear.
+type CacheClear struct {
+	Root string `json:"root"`
+}
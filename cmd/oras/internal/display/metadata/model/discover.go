@@ -33,6 +33,7 @@ type Discover struct {
 type Node struct {
 	Descriptor
 	Referrers []*Node `json:"referrers"`
+	Manifests []*Node `json:"manifests,omitempty"`
 }
 
 // AddReferrer adds a node to the discovered referrers tree.
@@ -47,6 +48,20 @@ func (d *Discover) AddReferrer(referrer, subject ocispec.Descriptor) error {
 	return nil
 }
 
+// AddManifest adds a child manifest of an index to the discovered referrers
+// tree, along with its platform.
+func (d *Discover) AddManifest(manifest, index ocispec.Descriptor) error {
+	parent, ok := d.nodes[index.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected index descriptor: %v", index)
+	}
+	child := NewNode(d.name, manifest)
+	child.Platform = manifest.Platform
+	d.nodes[child.Digest] = child
+	parent.Manifests = append(parent.Manifests, child)
+	return nil
+}
+
 // NewDiscover creates a new discover model.
 func NewDiscover(path string, root ocispec.Descriptor) Discover {
 	treeRoot := NewNode(path, root)
@@ -66,3 +81,20 @@ func NewNode(name string, desc ocispec.Descriptor) *Node {
 		Referrers:  []*Node{},
 	}
 }
+
+// Ancestor is an artifact in the discovered subject chain.
+type Ancestor struct {
+	Descriptor
+	Tags []string `json:"tags"`
+}
+
+// NewAncestor creates a new ancestor.
+func NewAncestor(name string, desc ocispec.Descriptor, tags []string) Ancestor {
+	if tags == nil {
+		tags = []string{}
+	}
+	return Ancestor{
+		Descriptor: FromDescriptor(name, desc),
+		Tags:       tags,
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Inspect is a model for an inspected artifact.
+type Inspect struct {
+	InspectedManifest
+	Tags      []string `json:"tags,omitempty"`
+	TotalSize int64    `json:"totalSize"`
+	Referrers []*Node  `json:"referrers"`
+
+	name      string
+	manifests map[digest.Digest]*InspectedManifest
+	referrers map[digest.Digest]*Node
+	counted   map[digest.Digest]bool
+}
+
+// InspectedManifest is an inspected manifest or index.
+type InspectedManifest struct {
+	Descriptor
+	Config    *InspectedConfig     `json:"config,omitempty"`
+	Layers    []ocispec.Descriptor `json:"layers,omitempty"`
+	Manifests []*InspectedManifest `json:"manifests,omitempty"`
+}
+
+// InspectedConfig is an inspected config, summarized if it is an image
+// config.
+type InspectedConfig struct {
+	ocispec.Descriptor
+	Created *time.Time `json:"created,omitempty"`
+	Author  string     `json:"author,omitempty"`
+}
+
+// NewInspect creates a new inspect model.
+func NewInspect(path string, root ocispec.Descriptor) *Inspect {
+	i := &Inspect{
+		InspectedManifest: InspectedManifest{
+			Descriptor: FromDescriptor(path, root),
+		},
+		Referrers: []*Node{},
+		name:      path,
+		referrers: make(map[digest.Digest]*Node),
+		counted:   make(map[digest.Digest]bool),
+	}
+	i.Platform = root.Platform
+	i.manifests = map[digest.Digest]*InspectedManifest{
+		root.Digest: &i.InspectedManifest,
+	}
+	i.count(root)
+	return i
+}
+
+// AddTags adds the tags resolving to the inspected artifact. Tags are left out
+// of the output if they are not looked up.
+func (i *Inspect) AddTags(tags []string) {
+	if i.Tags == nil {
+		i.Tags = []string{}
+	}
+	i.Tags = append(i.Tags, tags...)
+}
+
+// AddManifest adds a child manifest of an index, along with its platform.
+func (i *Inspect) AddManifest(manifest, index ocispec.Descriptor) error {
+	parent, ok := i.manifests[index.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected index descriptor: %v", index)
+	}
+	child := &InspectedManifest{
+		Descriptor: FromDescriptor(i.name, manifest),
+	}
+	child.Platform = manifest.Platform
+	i.manifests[manifest.Digest] = child
+	parent.Manifests = append(parent.Manifests, child)
+	i.count(manifest)
+	return nil
+}
+
+// SetConfig sets the config of a manifest. image is the parsed image config,
+// or nil if the config is not an image config.
+func (i *Inspect) SetConfig(config ocispec.Descriptor, image *ocispec.Image, manifest ocispec.Descriptor) error {
+	parent, ok := i.manifests[manifest.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected manifest descriptor: %v", manifest)
+	}
+	inspected := &InspectedConfig{
+		Descriptor: ocispec.Descriptor{
+			MediaType: config.MediaType,
+			Digest:    config.Digest,
+			Size:      config.Size,
+		},
+	}
+	if image != nil {
+		inspected.Created = image.Created
+		inspected.Author = image.Author
+		if image.OS != "" || image.Architecture != "" {
+			inspected.Platform = &ocispec.Platform{
+				OS:           image.OS,
+				Architecture: image.Architecture,
+				Variant:      image.Variant,
+			}
+		}
+	}
+	parent.Config = inspected
+	i.count(config)
+	return nil
+}
+
+// AddLayer adds a layer of a manifest.
+func (i *Inspect) AddLayer(layer, manifest ocispec.Descriptor) error {
+	parent, ok := i.manifests[manifest.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected manifest descriptor: %v", manifest)
+	}
+	parent.Layers = append(parent.Layers, layer)
+	i.count(layer)
+	return nil
+}
+
+// AddReferrer adds a referrer of the inspected artifact or of its referrers.
+func (i *Inspect) AddReferrer(referrer, subject ocispec.Descriptor) error {
+	node := NewNode(i.name, referrer)
+	if subject.Digest == i.Digest {
+		i.Referrers = append(i.Referrers, node)
+	} else {
+		parent, ok := i.referrers[subject.Digest]
+		if !ok {
+			return fmt.Errorf("unexpected subject descriptor: %v", subject)
+		}
+		parent.Referrers = append(parent.Referrers, node)
+	}
+	i.referrers[referrer.Digest] = node
+	return nil
+}
+
+// count adds the size of the content to the total size if not counted.
+func (i *Inspect) count(desc ocispec.Descriptor) {
+	if i.counted[desc.Digest] {
+		return
+	}
+	i.counted[desc.Digest] = true
+	i.TotalSize += desc.Size
+}
@@ -0,0 +1,51 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/manifest"
+)
+
+// ManifestDiff contains metadata formatted by oras manifest diff.
+type ManifestDiff struct {
+	From      Descriptor `json:"from"`
+	To        Descriptor `json:"to"`
+	Identical bool       `json:"identical"`
+	*manifest.Diff
+}
+
+// NewManifestDiff creates a new manifest diff model.
+func NewManifestDiff(fromReference string, from ocispec.Descriptor, toReference string, to ocispec.Descriptor, diff *manifest.Diff) ManifestDiff {
+	return ManifestDiff{
+		From:      newReferencedDescriptor(fromReference, from),
+		To:        newReferencedDescriptor(toReference, to),
+		Identical: diff.IsEmpty(),
+		Diff:      diff,
+	}
+}
+
+// newReferencedDescriptor creates a descriptor with the given reference.
+func newReferencedDescriptor(reference string, desc ocispec.Descriptor) Descriptor {
+	return Descriptor{
+		DigestReference: DigestReference{Reference: reference},
+		Descriptor: ocispec.Descriptor{
+			MediaType: desc.MediaType,
+			Digest:    desc.Digest,
+			Size:      desc.Size,
+		},
+	}
+}
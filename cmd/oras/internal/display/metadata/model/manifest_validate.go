@@ -0,0 +1,40 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/manifest"
+)
+
+// ManifestValidate contains metadata formatted by oras manifest validate.
+type ManifestValidate struct {
+	Descriptor
+	Valid    bool               `json:"valid"`
+	Findings []manifest.Finding `json:"findings"`
+}
+
+// NewManifestValidate creates a new manifest validate model.
+func NewManifestValidate(reference string, desc ocispec.Descriptor, findings []manifest.Finding) ManifestValidate {
+	if findings == nil {
+		findings = []manifest.Finding{}
+	}
+	return ManifestValidate{
+		Descriptor: newReferencedDescriptor(reference, desc),
+		Valid:      manifest.Count(findings, manifest.SeverityError) == 0,
+		Findings:   findings,
+	}
+}
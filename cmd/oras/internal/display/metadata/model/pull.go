@@ -54,25 +54,55 @@ func newFile(name string, outputDir string, desc ocispec.Descriptor, descPath st
 	}, nil
 }
 
+// PlatformFiles records the files pulled from a platform-specific manifest.
+type PlatformFiles struct {
+	DigestReference
+	Platform *ocispec.Platform `json:"platform"`
+	Files    []File            `json:"files"`
+}
+
+// ReferrerFiles records the files pulled from a referrer.
+type ReferrerFiles struct {
+	DigestReference
+	ArtifactType string `json:"artifactType"`
+	Files        []File `json:"files"`
+}
+
 type pull struct {
 	DigestReference
-	Files []File `json:"files"`
+	Files        []File          `json:"files"`
+	SkippedFiles []Descriptor    `json:"skippedFiles,omitempty"`
+	Platforms    []PlatformFiles `json:"platforms,omitempty"`
+	Referrers    []ReferrerFiles `json:"referrers,omitempty"`
 }
 
 // NewPull creates a new metadata struct for pull command.
-func NewPull(digestReference string, files []File) any {
+func NewPull(digestReference string, files []File, skipped []Descriptor, platforms []PlatformFiles, referrers []ReferrerFiles) any {
+	if files == nil {
+		// all files may be filtered out or exist in the output directory
+		files = []File{}
+	}
 	return pull{
 		DigestReference: DigestReference{
 			Reference: digestReference,
 		},
-		Files: files,
+		Files:        files,
+		SkippedFiles: skipped,
+		Platforms:    platforms,
+		Referrers:    referrers,
 	}
 }
 
-// Pulled records all pulled files.
+// Pulled records all pulled and skipped files. Files pulled after a platform
+// or a referrer is added are grouped into it.
 type Pulled struct {
-	lock  sync.Mutex
-	files []File
+	lock      sync.Mutex
+	files     []File
+	skipped   []Descriptor
+	platforms []PlatformFiles
+	referrers []ReferrerFiles
+	// addToGroup, if not nil, adds a file into the current group.
+	addToGroup func(File)
 }
 
 // Files returns all pulled files.
@@ -82,6 +112,73 @@ func (p *Pulled) Files() []File {
 	return slices.Clone(p.files)
 }
 
+// Skipped returns all skipped files.
+func (p *Pulled) Skipped() []Descriptor {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return slices.Clone(p.skipped)
+}
+
+// Platforms returns the pulled files grouped by platforms.
+func (p *Pulled) Platforms() []PlatformFiles {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	platforms := slices.Clone(p.platforms)
+	for i := range platforms {
+		platforms[i].Files = slices.Clone(platforms[i].Files)
+	}
+	return platforms
+}
+
+// AddPlatform adds a platform-specific manifest, where the subsequent pulled
+// files belong to.
+func (p *Pulled) AddPlatform(desc ocispec.Descriptor, descPath string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.platforms = append(p.platforms, PlatformFiles{
+		DigestReference: NewDigestReference(descPath, desc.Digest.String()),
+		Platform:        desc.Platform,
+		Files:           []File{},
+	})
+	i := len(p.platforms) - 1
+	p.addToGroup = func(file File) {
+		p.platforms[i].Files = append(p.platforms[i].Files, file)
+	}
+}
+
+// Referrers returns the pulled files grouped by referrers.
+func (p *Pulled) Referrers() []ReferrerFiles {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	referrers := slices.Clone(p.referrers)
+	for i := range referrers {
+		referrers[i].Files = slices.Clone(referrers[i].Files)
+	}
+	return referrers
+}
+
+// AddReferrer adds a referrer, where the subsequent pulled files belong to.
+func (p *Pulled) AddReferrer(desc ocispec.Descriptor, descPath string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.referrers = append(p.referrers, ReferrerFiles{
+		DigestReference: NewDigestReference(descPath, desc.Digest.String()),
+		ArtifactType:    desc.ArtifactType,
+		Files:           []File{},
+	})
+	i := len(p.referrers) - 1
+	p.addToGroup = func(file File) {
+		p.referrers[i].Files = append(p.referrers[i].Files, file)
+	}
+}
+
+// AddSkipped adds a skipped file.
+func (p *Pulled) AddSkipped(desc ocispec.Descriptor, descPath string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.skipped = append(p.skipped, FromDescriptor(descPath, desc))
+}
+
 // Add adds a pulled file.
 func (p *Pulled) Add(name string, outputDir string, desc ocispec.Descriptor, descPath string) error {
 	p.lock.Lock()
@@ -91,5 +188,8 @@ func (p *Pulled) Add(name string, outputDir string, desc ocispec.Descriptor, des
 		return err
 	}
 	p.files = append(p.files, file)
+	if p.addToGroup != nil {
+		p.addToGroup(file)
+	}
 	return nil
 }
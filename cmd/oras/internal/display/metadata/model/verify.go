@@ -0,0 +1,54 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+// Signature contains metadata of a signature checked by oras verify.
+type Signature struct {
+	Digest string `json:"digest"`
+	Valid  bool   `json:"valid"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Verify contains metadata formatted by oras verify.
+type Verify struct {
+	Descriptor
+	Verified   bool        `json:"verified"`
+	Signatures []Signature `json:"signatures"`
+}
+
+// NewVerify creates a new verify model.
+func NewVerify(reference string, subject ocispec.Descriptor) *Verify {
+	return &Verify{
+		Descriptor: newReferencedDescriptor(reference, subject),
+		Signatures: []Signature{},
+	}
+}
+
+// AddSignature adds a checked signature to the metadata. err is the
+// verification error if the signature is invalid.
+func (v *Verify) AddSignature(sig ocispec.Descriptor, err error) {
+	signature := Signature{
+		Digest: sig.Digest.String(),
+		Valid:  err == nil,
+	}
+	if err != nil {
+		signature.Error = err.Error()
+	}
+	v.Verified = v.Verified || signature.Valid
+	v.Signatures = append(v.Signatures, signature)
+}
@@ -33,7 +33,7 @@ type discoverHandler struct {
 }
 
 // NewDiscoverHandler creates a new handler for discover events.
-func NewDiscoverHandler(out io.Writer, root ocispec.Descriptor, path string, template string) metadata.DiscoverHandler {
+func NewDiscoverHandler(out io.Writer, root ocispec.Descriptor, path string, template string) metadata.DiscoverManifestHandler {
 	return &discoverHandler{
 		out:      out,
 		path:     path,
@@ -47,7 +47,43 @@ func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) err
 	return h.model.AddReferrer(referrer, subject)
 }
 
+// OnManifestDiscovered implements metadata.DiscoverManifestHandler.
+func (h *discoverHandler) OnManifestDiscovered(manifest, index ocispec.Descriptor) error {
+	return h.model.AddManifest(manifest, index)
+}
+
 // Render implements metadata.DiscoverHandler.
 func (h *discoverHandler) Render() error {
 	return output.ParseAndWrite(h.out, h.model.Root, h.template)
 }
+
+// discoverAncestorsHandler handles template metadata output for discovering the
+// subject chain.
+type discoverAncestorsHandler struct {
+	template  string
+	path      string
+	out       io.Writer
+	ancestors []model.Ancestor
+}
+
+// NewDiscoverAncestorsHandler creates a new handler for discovering the
+// subject chain.
+func NewDiscoverAncestorsHandler(out io.Writer, path string, template string) metadata.DiscoverAncestorsHandler {
+	return &discoverAncestorsHandler{
+		out:       out,
+		path:      path,
+		template:  template,
+		ancestors: []model.Ancestor{},
+	}
+}
+
+// OnAncestorDiscovered implements metadata.DiscoverAncestorsHandler.
+func (h *discoverAncestorsHandler) OnAncestorDiscovered(node ocispec.Descriptor, tags []string) error {
+	h.ancestors = append(h.ancestors, model.NewAncestor(h.path, node, tags))
+	return nil
+}
+
+// Render implements metadata.DiscoverAncestorsHandler.
+func (h *discoverAncestorsHandler) Render() error {
+	return output.ParseAndWrite(h.out, h.ancestors, h.template)
+}
@@ -50,7 +50,7 @@ func (ph *PullHandler) OnPulled(_ *option.Target, desc ocispec.Descriptor) {
 
 // Render implements metadata.PullHandler.
 func (ph *PullHandler) Render() error {
-	return output.ParseAndWrite(ph.out, model.NewPull(ph.path+"@"+ph.root.Digest.String(), ph.pulled.Files()), ph.template)
+	return output.ParseAndWrite(ph.out, model.NewPull(ph.path+"@"+ph.root.Digest.String(), ph.pulled.Files(), ph.pulled.Skipped(), ph.pulled.Platforms(), ph.pulled.Referrers()), ph.template)
 }
 
 // OnFilePulled implements metadata.PullHandler.
@@ -58,6 +58,24 @@ func (ph *PullHandler) OnFilePulled(name string, outputDir string, desc ocispec.
 	return ph.pulled.Add(name, outputDir, desc, descPath)
 }
 
+// OnFileSkipped implements metadata.PullHandler.
+func (ph *PullHandler) OnFileSkipped(_ string, desc ocispec.Descriptor, descPath string) error {
+	ph.pulled.AddSkipped(desc, descPath)
+	return nil
+}
+
+// OnPlatformPulling implements metadata.PullHandler.
+func (ph *PullHandler) OnPlatformPulling(desc ocispec.Descriptor) error {
+	ph.pulled.AddPlatform(desc, ph.path)
+	return nil
+}
+
+// OnReferrerPulling implements metadata.PullHandler.
+func (ph *PullHandler) OnReferrerPulling(desc ocispec.Descriptor) error {
+	ph.pulled.AddReferrer(desc, ph.path)
+	return nil
+}
+
 // OnLayerSkipped implements metadata.PullHandler.
 func (ph *PullHandler) OnLayerSkipped(ocispec.Descriptor) error {
 	return nil
@@ -0,0 +1,135 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+)
+
+// cacheListHandler handles text metadata output for cache ls command.
+type cacheListHandler struct {
+	out *tabwriter.Writer
+}
+
+// NewCacheListHandler creates a new handler for cache ls events.
+func NewCacheListHandler(out io.Writer) metadata.CacheListHandler {
+	return &cacheListHandler{
+		out: tabwriter.NewWriter(out, 0, 0, 2, ' ', 0),
+	}
+}
+
+// OnBlobListed implements metadata.CacheListHandler.
+func (h *cacheListHandler) OnBlobListed(desc ocispec.Descriptor, lastAccess time.Time) error {
+	_, err := fmt.Fprintf(h.out, "%s\t%s\t%s\n", desc.Digest, humanize.ToBytes(desc.Size), lastAccess.Format(time.DateTime))
+	return err
+}
+
+// Render implements metadata.CacheListHandler.
+func (h *cacheListHandler) Render() error {
+	return h.out.Flush()
+}
+
+// cacheUsageHandler handles text metadata output for cache du command.
+type cacheUsageHandler struct {
+	out   io.Writer
+	root  string
+	count int
+	size  int64
+}
+
+// NewCacheUsageHandler creates a new handler for cache du events.
+func NewCacheUsageHandler(out io.Writer, root string) metadata.CacheListHandler {
+	return &cacheUsageHandler{
+		out:  out,
+		root: root,
+	}
+}
+
+// OnBlobListed implements metadata.CacheListHandler.
+func (h *cacheUsageHandler) OnBlobListed(desc ocispec.Descriptor, _ time.Time) error {
+	h.count++
+	h.size += desc.Size
+	return nil
+}
+
+// Render implements metadata.CacheListHandler.
+func (h *cacheUsageHandler) Render() error {
+	_, err := fmt.Fprintf(h.out, "%s\t%d blobs\t%s\n", humanize.ToBytes(h.size), h.count, h.root)
+	return err
+}
+
+// cachePruneHandler handles text metadata output for cache prune command.
+type cachePruneHandler struct {
+	out    io.Writer
+	dryRun bool
+	count  int
+	size   int64
+}
+
+// NewCachePruneHandler creates a new handler for cache prune events.
+func NewCachePruneHandler(out io.Writer, dryRun bool) metadata.CachePruneHandler {
+	return &cachePruneHandler{
+		out:    out,
+		dryRun: dryRun,
+	}
+}
+
+// OnBlobPruned implements metadata.CachePruneHandler.
+func (h *cachePruneHandler) OnBlobPruned(desc ocispec.Descriptor, _ time.Time) error {
+	h.count++
+	h.size += desc.Size
+	status := "Removed"
+	if h.dryRun {
+		status = "Would remove"
+	}
+	_, err := fmt.Fprintln(h.out, status, desc.Digest, humanize.ToBytes(desc.Size))
+	return err
+}
+
+// Render implements metadata.CachePruneHandler.
+func (h *cachePruneHandler) Render() error {
+	format := "Pruned %d blobs, freed %s\n"
+	if h.dryRun {
+		format = "Would prune %d blobs, freeing %s\n"
+	}
+	_, err := fmt.Fprintf(h.out, format, h.count, humanize.ToBytes(h.size))
+	return err
+}
+
+// cacheClearHandler handles text metadata output for cache clear command.
+type cacheClearHandler struct {
+	out io.Writer
+}
+
+// NewCacheClearHandler creates a new handler for cache clear events.
+func NewCacheClearHandler(out io.Writer) metadata.CacheClearHandler {
+	return &cacheClearHandler{
+		out: out,
+	}
+}
+
+// OnCleared implements metadata.CacheClearHandler.
+func (h *cacheClearHandler) OnCleared(root string) error {
+	_, err := fmt.Fprintln(h.out, "Cleared", root)
+	return err
+}
@@ -0,0 +1,145 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// changeMarks are the marks of the change types.
+var changeMarks = map[string]string{
+	manifest.ChangeAdded:   "+",
+	manifest.ChangeRemoved: "-",
+	manifest.ChangeChanged: "~",
+}
+
+// ManifestDiffHandler handles text metadata output for manifest diff events.
+type ManifestDiffHandler struct {
+	printer *output.Printer
+}
+
+// NewManifestDiffHandler returns a new handler for manifest diff events.
+func NewManifestDiffHandler(printer *output.Printer) metadata.ManifestDiffHandler {
+	return &ManifestDiffHandler{
+		printer: printer,
+	}
+}
+
+// OnCompared implements metadata.ManifestDiffHandler.
+func (h *ManifestDiffHandler) OnCompared(target *option.BinaryTarget, from, to ocispec.Descriptor, diff *manifest.Diff) error {
+	lines := []string{
+		"--- " + target.From.GetDigestReference(from),
+		"+++ " + target.To.GetDigestReference(to),
+	}
+	if diff.IsEmpty() {
+		lines = append(lines, "No differences found")
+	}
+	lines = append(lines, formatDiff(diff, "")...)
+	for _, line := range lines {
+		if err := h.printer.Println(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatDiff formats the differences with each line prefixed by indent.
+func formatDiff(diff *manifest.Diff, indent string) []string {
+	var lines []string
+	if diff.MediaType != nil {
+		lines = append(lines, fmt.Sprintf("%smediaType: %s -> %s", indent, orNone(diff.MediaType.From), orNone(diff.MediaType.To)))
+	}
+	if diff.ArtifactType != nil {
+		lines = append(lines, fmt.Sprintf("%sartifactType: %s -> %s", indent, orNone(diff.ArtifactType.From), orNone(diff.ArtifactType.To)))
+	}
+	if diff.Config != nil {
+		lines = append(lines, fmt.Sprintf("%sconfig: %s -> %s", indent, describe(diff.Config.From), describe(diff.Config.To)))
+	}
+	if diff.Subject != nil {
+		lines = append(lines, fmt.Sprintf("%ssubject: %s -> %s", indent, describe(diff.Subject.From), describe(diff.Subject.To)))
+	}
+	if len(diff.Annotations) > 0 {
+		lines = append(lines, indent+"annotations:")
+		for _, change := range diff.Annotations {
+			line := fmt.Sprintf("%s  %s %s: ", indent, changeMarks[change.Type], change.Key)
+			switch change.Type {
+			case manifest.ChangeAdded:
+				line += *change.To
+			case manifest.ChangeRemoved:
+				line += *change.From
+			default:
+				line += *change.From + " -> " + *change.To
+			}
+			lines = append(lines, line)
+		}
+	}
+	if len(diff.Layers) > 0 {
+		lines = append(lines, indent+"layers:")
+		for _, change := range diff.Layers {
+			name := change.Title
+			if name == "" {
+				name = "<untitled>"
+			}
+			lines = append(lines, indent+formatChange(change.Type, name, change.From, change.To))
+		}
+	}
+	if len(diff.Manifests) > 0 {
+		lines = append(lines, indent+"manifests:")
+		for _, change := range diff.Manifests {
+			lines = append(lines, indent+formatChange(change.Type, change.Platform, change.From, change.To))
+			if change.Diff != nil {
+				lines = append(lines, formatDiff(change.Diff, indent+"    ")...)
+			}
+		}
+	}
+	return lines
+}
+
+// formatChange formats the change of a named descriptor.
+func formatChange(changeType, name string, from, to *ocispec.Descriptor) string {
+	line := fmt.Sprintf("  %s %s: ", changeMarks[changeType], name)
+	switch changeType {
+	case manifest.ChangeAdded:
+		return line + describe(to)
+	case manifest.ChangeRemoved:
+		return line + describe(from)
+	default:
+		return line + describe(from) + " -> " + describe(to)
+	}
+}
+
+// describe returns the digest and the size of the optional descriptor.
+func describe(desc *ocispec.Descriptor) string {
+	if desc == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%s (%s)", desc.Digest, humanize.ToBytes(desc.Size))
+}
+
+// orNone returns the value, or "<none>" if it is empty.
+func orNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
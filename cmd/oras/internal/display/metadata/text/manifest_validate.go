@@ -0,0 +1,72 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// ManifestValidateHandler handles text metadata output for manifest validate
+// events.
+type ManifestValidateHandler struct {
+	printer *output.Printer
+}
+
+// NewManifestValidateHandler returns a new handler for manifest validate
+// events.
+func NewManifestValidateHandler(printer *output.Printer) metadata.ManifestValidateHandler {
+	return &ManifestValidateHandler{
+		printer: printer,
+	}
+}
+
+// OnValidated implements metadata.ManifestValidateHandler.
+func (h *ManifestValidateHandler) OnValidated(target *option.Target, desc ocispec.Descriptor, findings []manifest.Finding) error {
+	if err := h.printer.Println("Validated", target.GetDigestReference(desc)); err != nil {
+		return err
+	}
+	for _, finding := range findings {
+		line := fmt.Sprintf("[%s] %s", finding.Severity, finding.Message)
+		if finding.Field != "" {
+			line = fmt.Sprintf("[%s] %s %s", finding.Severity, finding.Field, finding.Message)
+		}
+		if err := h.printer.Println(line); err != nil {
+			return err
+		}
+	}
+	if len(findings) == 0 {
+		return h.printer.Println("No issues found")
+	}
+	return h.printer.Println(fmt.Sprintf("Found %s, %s and %s",
+		countOf(manifest.Count(findings, manifest.SeverityError), "error"),
+		countOf(manifest.Count(findings, manifest.SeverityWarning), "warning"),
+		countOf(manifest.Count(findings, manifest.SeverityInfo), "info finding"),
+	))
+}
+
+// countOf returns the count of the noun in plural form if needed.
+func countOf(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
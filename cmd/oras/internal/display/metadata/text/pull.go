@@ -28,6 +28,7 @@ import (
 type PullHandler struct {
 	printer      *output.Printer
 	layerSkipped atomic.Bool
+	fileSkipped  atomic.Int64
 	target       *option.Target
 	root         ocispec.Descriptor
 }
@@ -49,6 +50,22 @@ func (ph *PullHandler) OnLayerSkipped(ocispec.Descriptor) error {
 	return nil
 }
 
+// OnFileSkipped implements metadata.PullHandler.
+func (ph *PullHandler) OnFileSkipped(_ string, _ ocispec.Descriptor, _ string) error {
+	ph.fileSkipped.Add(1)
+	return nil
+}
+
+// OnPlatformPulling implements metadata.PullHandler.
+func (ph *PullHandler) OnPlatformPulling(ocispec.Descriptor) error {
+	return nil
+}
+
+// OnReferrerPulling implements metadata.PullHandler.
+func (ph *PullHandler) OnReferrerPulling(ocispec.Descriptor) error {
+	return nil
+}
+
 // OnPulled implements metadata.PullHandler.
 func (ph *PullHandler) OnPulled(target *option.Target, desc ocispec.Descriptor) {
 	ph.target = target
@@ -57,6 +74,9 @@ func (ph *PullHandler) OnPulled(target *option.Target, desc ocispec.Descriptor)
 
 // Render implements metadata.PullHandler.
 func (ph *PullHandler) Render() error {
+	if count := ph.fileSkipped.Load(); count > 0 {
+		_ = ph.printer.Printf("Skipped %d file(s) not matching the filters\n", count)
+	}
 	if ph.layerSkipped.Load() {
 		_ = ph.printer.Printf("Skipped pulling layers without file name in %q\n", ocispec.AnnotationTitle)
 		_ = ph.printer.Printf("Use 'oras copy %s --to-oci-layout <layout-dir>' to pull all layers.\n", ph.target.RawReference)
@@ -0,0 +1,59 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// VerifyHandler handles text metadata output for verify events.
+type VerifyHandler struct {
+	printer   *output.Printer
+	reference string
+	subject   ocispec.Descriptor
+	verified  bool
+}
+
+// NewVerifyHandler returns a new handler for verify events.
+func NewVerifyHandler(printer *output.Printer, reference string, subject ocispec.Descriptor) metadata.VerifyHandler {
+	return &VerifyHandler{
+		printer:   printer,
+		reference: reference,
+		subject:   subject,
+	}
+}
+
+// OnSignatureVerified implements metadata.VerifyHandler.
+func (h *VerifyHandler) OnSignatureVerified(sig ocispec.Descriptor, err error) error {
+	if err != nil {
+		return h.printer.Println("Invalid ", sig.Digest, err)
+	}
+	h.verified = true
+	return h.printer.Println("Valid   ", sig.Digest)
+}
+
+// Render implements metadata.VerifyHandler.
+func (h *VerifyHandler) Render() error {
+	if !h.verified {
+		return nil
+	}
+	if err := h.printer.Println("Verified", h.reference); err != nil {
+		return err
+	}
+	return h.printer.Println("Digest:", h.subject.Digest)
+}
@@ -26,6 +26,7 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"gopkg.in/yaml.v3"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/internal/descriptor"
 	"oras.land/oras/internal/tree"
 )
 
@@ -33,6 +34,7 @@ var (
 	artifactTypeColor = aec.LightYellowF
 	digestColor       = aec.LightGreenF
 	annotationsColor  = aec.LightCyanF
+	platformColor     = aec.LightMagentaF
 )
 
 // discoverHandler handles json metadata output for discover events.
@@ -46,7 +48,7 @@ type discoverHandler struct {
 }
 
 // NewDiscoverHandler creates a new handler for discover events.
-func NewDiscoverHandler(out io.Writer, path string, root ocispec.Descriptor, verbose bool, tty *os.File) metadata.DiscoverHandler {
+func NewDiscoverHandler(out io.Writer, path string, root ocispec.Descriptor, verbose bool, tty *os.File) metadata.DiscoverManifestHandler {
 	rootDigest := fmt.Sprintf("%s@%s", path, root.Digest)
 	if tty != nil {
 		rootDigest = digestColor.Apply(rootDigest)
@@ -102,7 +104,86 @@ func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) err
 	return nil
 }
 
+// OnManifestDiscovered implements metadata.DiscoverManifestHandler.
+func (h *discoverHandler) OnManifestDiscovered(manifest, index ocispec.Descriptor) error {
+	node, ok := h.nodes[index.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected index descriptor: %v", index)
+	}
+
+	// group the child manifest by its platform
+	platform := descriptor.PlatformString(manifest)
+	if platform == "" {
+		platform = "<unknown>"
+	}
+	platform = "[platform] " + platform
+	dgst := manifest.Digest.String()
+	if h.tty != nil {
+		platform = platformColor.Apply(platform)
+		dgst = digestColor.Apply(dgst)
+	}
+	h.nodes[manifest.Digest] = node.AddPath(platform, dgst)
+	return nil
+}
+
 // Render implements metadata.DiscoverHandler.
 func (h *discoverHandler) Render() error {
 	return tree.NewPrinter(h.out).Print(h.root)
 }
+
+// discoverAncestorsHandler handles tree metadata output for discovering the
+// subject chain.
+type discoverAncestorsHandler struct {
+	out  io.Writer
+	path string
+	root *tree.Node
+	last *tree.Node
+	tty  *os.File
+}
+
+// NewDiscoverAncestorsHandler creates a new handler for discovering the
+// subject chain.
+func NewDiscoverAncestorsHandler(out io.Writer, path string, tty *os.File) metadata.DiscoverAncestorsHandler {
+	return &discoverAncestorsHandler{
+		out:  out,
+		path: path,
+		tty:  tty,
+	}
+}
+
+// OnAncestorDiscovered implements metadata.DiscoverAncestorsHandler.
+func (h *discoverAncestorsHandler) OnAncestorDiscovered(node ocispec.Descriptor, tags []string) error {
+	reference := fmt.Sprintf("%s@%s", h.path, node.Digest)
+	artifactType := node.ArtifactType
+	if artifactType == "" {
+		artifactType = "<unknown>"
+	}
+	artifactType = "[artifactType] " + artifactType
+	if h.tty != nil {
+		reference = digestColor.Apply(reference)
+		artifactType = artifactTypeColor.Apply(artifactType)
+	}
+
+	var current *tree.Node
+	if h.last == nil {
+		current = tree.New(reference)
+		h.root = current
+	} else {
+		subjectTitle := "[subject] " + reference
+		current = h.last.Add(subjectTitle)
+	}
+	current.Add(artifactType)
+	if len(tags) > 0 {
+		current.Add("[tags] " + strings.Join(tags, ", "))
+	}
+	h.last = current
+	return nil
+}
+
+// Render implements metadata.DiscoverAncestorsHandler.
+func (h *discoverAncestorsHandler) Render() error {
+	if h.root == nil {
+		return nil
+	}
+	return tree.NewPrinter(h.out).Print(h.root)
+}
@@ -125,3 +125,84 @@ func TestDiscoverHandler_OnDiscovered(t *testing.T) {
 		}
 	})
 }
+
+func TestDiscoverHandler_OnManifestDiscovered(t *testing.T) {
+	index := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2",
+		Size:      529,
+	}
+	manifest := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d",
+		Size:      660,
+		Platform: &ocispec.Platform{
+			OS:           "linux",
+			Architecture: "arm",
+			Variant:      "v7",
+		},
+	}
+	referrer := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		Digest:       "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Size:         3,
+		ArtifactType: "test/sig",
+	}
+	var buf bytes.Buffer
+	h := NewDiscoverHandler(&buf, "localhost:5000/test", index, false, nil)
+	if err := h.OnManifestDiscovered(manifest, index); err != nil {
+		t.Fatal("OnManifestDiscovered() error =", err)
+	}
+	if err := h.OnDiscovered(referrer, manifest); err != nil {
+		t.Fatal("OnDiscovered() error =", err)
+	}
+	if err := h.OnManifestDiscovered(manifest, ocispec.Descriptor{Digest: "sha256:unknown"}); err == nil {
+		t.Error("OnManifestDiscovered() error = nil, want error")
+	}
+	if err := h.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+	want := `localhost:5000/test@sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2
+└── [platform] linux/arm/v7
+    └── sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d
+        └── test/sig
+            └── sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %s, want %s", got, want)
+	}
+}
+
+func TestDiscoverAncestorsHandler_Render(t *testing.T) {
+	signature := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Size:      3,
+	}
+	subject := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		Digest:       "sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d",
+		Size:         660,
+		ArtifactType: "test/image",
+	}
+	var buf bytes.Buffer
+	h := NewDiscoverAncestorsHandler(&buf, "localhost:5000/test", nil)
+	if err := h.OnAncestorDiscovered(signature, nil); err != nil {
+		t.Fatal("OnAncestorDiscovered() error =", err)
+	}
+	if err := h.OnAncestorDiscovered(subject, []string{"v1", "latest"}); err != nil {
+		t.Fatal("OnAncestorDiscovered() error =", err)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+	want := `localhost:5000/test@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+├── [artifactType] <unknown>
+└── [subject] localhost:5000/test@sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d
+    ├── [artifactType] test/image
+    └── [tags] v1, latest
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/tree"
+)
+
+// inspectHandler handles tree metadata output for inspect events.
+type inspectHandler struct {
+	out   io.Writer
+	model *model.Inspect
+	tty   *os.File
+}
+
+// NewInspectHandler creates a new handler for inspect events.
+func NewInspectHandler(out io.Writer, path string, root ocispec.Descriptor, tty *os.File) metadata.InspectHandler {
+	return &inspectHandler{
+		out:   out,
+		model: model.NewInspect(path, root),
+		tty:   tty,
+	}
+}
+
+// OnTagsFound implements metadata.InspectHandler.
+func (h *inspectHandler) OnTagsFound(tags []string) error {
+	h.model.AddTags(tags)
+	return nil
+}
+
+// OnManifestInspected implements metadata.InspectHandler.
+func (h *inspectHandler) OnManifestInspected(manifest, index ocispec.Descriptor) error {
+	return h.model.AddManifest(manifest, index)
+}
+
+// OnConfigInspected implements metadata.InspectHandler.
+func (h *inspectHandler) OnConfigInspected(config ocispec.Descriptor, image *ocispec.Image, manifest ocispec.Descriptor) error {
+	return h.model.SetConfig(config, image, manifest)
+}
+
+// OnLayerInspected implements metadata.InspectHandler.
+func (h *inspectHandler) OnLayerInspected(layer, manifest ocispec.Descriptor) error {
+	return h.model.AddLayer(layer, manifest)
+}
+
+// OnReferrerDiscovered implements metadata.InspectHandler.
+func (h *inspectHandler) OnReferrerDiscovered(referrer, subject ocispec.Descriptor) error {
+	return h.model.AddReferrer(referrer, subject)
+}
+
+// Render implements metadata.InspectHandler.
+func (h *inspectHandler) Render() error {
+	root := tree.New(h.digest(h.model.Reference))
+	if len(h.model.Tags) > 0 {
+		root.Add("[tags] " + strings.Join(h.model.Tags, ", "))
+	}
+	h.addManifest(root, &h.model.InspectedManifest)
+	root.Add("[total size] " + humanize.ToBytes(h.model.TotalSize).String())
+	if len(h.model.Referrers) > 0 {
+		h.addReferrers(root.Add("[referrers]"), h.model.Referrers)
+	}
+	return tree.NewPrinter(h.out).Print(root)
+}
+
+// addManifest adds the details of the manifest to the node, recursing into
+// the child manifests grouped by platform.
+func (h *inspectHandler) addManifest(node *tree.Node, manifest *model.InspectedManifest) {
+	node.Add("[mediaType] " + manifest.MediaType)
+	if manifest.ArtifactType != "" {
+		node.Add("[artifactType] " + h.artifactType(manifest.ArtifactType))
+	}
+	node.Add("[size] " + humanize.ToBytes(manifest.Size).String())
+	if config := manifest.Config; config != nil {
+		configNode := node.Add("[config] " + h.digest(config.Digest.String()))
+		configNode.Add("[mediaType] " + config.MediaType)
+		configNode.Add("[size] " + humanize.ToBytes(config.Size).String())
+		if platform := descriptor.PlatformString(config.Descriptor); platform != "" {
+			configNode.Add("[platform] " + platform)
+		}
+		if config.Created != nil {
+			configNode.Add("[created] " + config.Created.Format(time.RFC3339))
+		}
+		if config.Author != "" {
+			configNode.Add("[author] " + config.Author)
+		}
+	}
+	if len(manifest.Layers) > 0 {
+		layersNode := node.Add("[layers]")
+		for _, layer := range manifest.Layers {
+			name, _ := descriptor.GetTitleOrMediaType(layer)
+			layersNode.Add(fmt.Sprintf("%s %s (%s)", name, h.digest(layer.Digest.String()), humanize.ToBytes(layer.Size)))
+		}
+	}
+	for _, child := range manifest.Manifests {
+		platform := descriptor.PlatformString(child.Descriptor.Descriptor)
+		if platform == "" {
+			platform = "<unknown>"
+		}
+		platform = "[platform] " + platform
+		if h.tty != nil {
+			platform = platformColor.Apply(platform)
+		}
+		h.addManifest(node.AddPath(platform, h.digest(child.Digest.String())), child)
+	}
+}
+
+// addReferrers adds the referrers grouped by artifact type to the node,
+// recursing into the referrers of the referrers.
+func (h *inspectHandler) addReferrers(node *tree.Node, referrers []*model.Node) {
+	for _, referrer := range referrers {
+		artifactType := referrer.ArtifactType
+		if artifactType == "" {
+			artifactType = "<unknown>"
+		}
+		h.addReferrers(node.AddPath(h.artifactType(artifactType), h.digest(referrer.Digest.String())), referrer.Referrers)
+	}
+}
+
+// digest applies the digest color to the value in a terminal.
+func (h *inspectHandler) digest(value string) string {
+	if h.tty != nil {
+		return digestColor.Apply(value)
+	}
+	return value
+}
+
+// artifactType applies the artifact type color to the value in a terminal.
+func (h *inspectHandler) artifactType(value string) string {
+	if h.tty != nil {
+		return artifactTypeColor.Apply(value)
+	}
+	return value
+}
@@ -0,0 +1,116 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestInspectHandler_Render(t *testing.T) {
+	index := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2",
+		Size:      529,
+	}
+	manifest := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d",
+		Size:      660,
+		Platform: &ocispec.Platform{
+			OS:           "linux",
+			Architecture: "amd64",
+		},
+	}
+	config := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageConfig,
+		Digest:    "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+		Size:      2,
+	}
+	created := time.Date(2023, 1, 18, 8, 37, 42, 0, time.UTC)
+	image := &ocispec.Image{
+		Created:  &created,
+		Platform: ocispec.Platform{OS: "linux", Architecture: "amd64"},
+	}
+	layer := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    "sha256:fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9",
+		Size:      3,
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: "bar",
+		},
+	}
+	referrer := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		Digest:       "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Size:         3,
+		ArtifactType: "test/sig",
+	}
+
+	var buf bytes.Buffer
+	h := NewInspectHandler(&buf, "localhost:5000/test", index, nil)
+	if err := h.OnTagsFound([]string{"v1", "latest"}); err != nil {
+		t.Fatal("OnTagsFound() error =", err)
+	}
+	if err := h.OnManifestInspected(manifest, index); err != nil {
+		t.Fatal("OnManifestInspected() error =", err)
+	}
+	if err := h.OnConfigInspected(config, image, manifest); err != nil {
+		t.Fatal("OnConfigInspected() error =", err)
+	}
+	// the same layer is counted once in the total size
+	for range 2 {
+		if err := h.OnLayerInspected(layer, manifest); err != nil {
+			t.Fatal("OnLayerInspected() error =", err)
+		}
+	}
+	if err := h.OnReferrerDiscovered(referrer, index); err != nil {
+		t.Fatal("OnReferrerDiscovered() error =", err)
+	}
+	if err := h.OnLayerInspected(layer, referrer); err == nil {
+		t.Error("OnLayerInspected() error = nil, want error")
+	}
+	if err := h.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+	want := `localhost:5000/test@sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2
+├── [tags] v1, latest
+├── [mediaType] application/vnd.oci.image.index.v1+json
+├── [size] 529  B
+├── [platform] linux/amd64
+│   └── sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d
+│       ├── [mediaType] application/vnd.oci.image.manifest.v1+json
+│       ├── [size] 660  B
+│       ├── [config] sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a
+│       │   ├── [mediaType] application/vnd.oci.image.config.v1+json
+│       │   ├── [size] 2  B
+│       │   ├── [platform] linux/amd64
+│       │   └── [created] 2023-01-18T08:37:42Z
+│       └── [layers]
+│           ├── bar sha256:fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9 (3  B)
+│           └── bar sha256:fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9 (3  B)
+├── [total size] 1.17 KB
+└── [referrers]
+    └── test/sig
+        └── sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %s, want %s", got, want)
+	}
+}
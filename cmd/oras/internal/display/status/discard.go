@@ -40,6 +40,16 @@ func (DiscardHandler) OnFileLoading(name string) error {
 	return nil
 }
 
+// OnMediaTypeDetected is called when the media type of a file is detected.
+func (DiscardHandler) OnMediaTypeDetected(name string, mediaType string) error {
+	return nil
+}
+
+// OnFileExcluded is called when a file is excluded by the filters.
+func (DiscardHandler) OnFileExcluded(name string) error {
+	return nil
+}
+
 // OnEmptyArtifact is called when no file is loaded for an artifact push.
 func (DiscardHandler) OnEmptyArtifact() error {
 	return nil
@@ -90,6 +100,11 @@ func (DiscardHandler) OnNodeSkipped(desc ocispec.Descriptor) error {
 	return nil
 }
 
+// OnNodeExists implements PullHandler.
+func (DiscardHandler) OnNodeExists(desc ocispec.Descriptor) error {
+	return nil
+}
+
 // OnFetching implements referenceFetchHandler.
 func (DiscardHandler) OnFetching(string) error {
 	return nil
@@ -48,3 +48,17 @@ func TestDiscardHandler_OnIndexPushed(t *testing.T) {
 		t.Errorf("DiscardHandler.OnIndexPushed() error = %v, wantErr nil", err)
 	}
 }
+
+func TestDiscardHandler_OnMediaTypeDetected(t *testing.T) {
+	testDiscard := NewDiscardHandler()
+	if err := testDiscard.OnMediaTypeDetected("sbom.json", "application/spdx+json"); err != nil {
+		t.Errorf("DiscardHandler.OnMediaTypeDetected() error = %v, wantErr nil", err)
+	}
+}
+
+func TestDiscardHandler_OnFileExcluded(t *testing.T) {
+	testDiscard := NewDiscardHandler()
+	if err := testDiscard.OnFileExcluded("bin/app.pdb"); err != nil {
+		t.Errorf("DiscardHandler.OnFileExcluded() error = %v, wantErr nil", err)
+	}
+}
@@ -29,6 +29,8 @@ type StopTrackTargetFunc func() error
 // PushHandler handles status output for push command.
 type PushHandler interface {
 	OnFileLoading(name string) error
+	OnMediaTypeDetected(name string, mediaType string) error
+	OnFileExcluded(name string) error
 	OnEmptyArtifact() error
 	TrackTarget(gt oras.GraphTarget) (oras.GraphTarget, StopTrackTargetFunc, error)
 	OnCopySkipped(ctx context.Context, desc ocispec.Descriptor) error
@@ -54,6 +56,9 @@ type PullHandler interface {
 	OnNodeRestored(desc ocispec.Descriptor) error
 	// OnNodeSkipped is called when a node is skipped.
 	OnNodeSkipped(desc ocispec.Descriptor) error
+	// OnNodeExists is called when a node is skipped since it already exists
+	// in the output.
+	OnNodeExists(desc ocispec.Descriptor) error
 }
 
 // CopyHandler handles status output for cp command.
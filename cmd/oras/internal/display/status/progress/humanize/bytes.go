@@ -18,6 +18,8 @@ package humanize
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 )
 
 const base = 1024.0
@@ -58,3 +60,26 @@ func RoundTo(size float64) float64 {
 	}
 	return math.Round(size)
 }
+
+// ParseBytes parses a human readable size, e.g. "512", "64K", "5G" or
+// "1.5GiB", into a size in bytes. Units are in base 1024.
+func ParseBytes(s string) (int64, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+	str = strings.TrimSuffix(strings.TrimSuffix(str, "IB"), "B")
+	exp := 0
+	if n := len(str); n > 0 {
+		if i := strings.IndexByte("KMGT", str[n-1]); i >= 0 {
+			exp = i + 1
+			str = strings.TrimSpace(str[:n-1])
+		}
+	}
+	size, err := strconv.ParseFloat(str, 64)
+	if err != nil || size < 0 || math.IsInf(size, 0) {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	size *= math.Pow(base, float64(exp))
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("size %q is too large", s)
+	}
+	return int64(size), nil
+}
@@ -70,3 +70,35 @@ func TestToBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    int64
+		wantErr bool
+	}{
+		{"bytes", "512", 512, false},
+		{"bytes with unit", "512B", 512, false},
+		{"kilobytes", "64K", 64 * 1024, false},
+		{"megabytes", "10MB", 10 * 1024 * 1024, false},
+		{"gigabytes", "5G", 5 * 1024 * 1024 * 1024, false},
+		{"gibibytes", "1.5GiB", 1536 * 1024 * 1024, false},
+		{"lower case", "2tb", 2 * 1024 * 1024 * 1024 * 1024, false},
+		{"empty", "", 0, true},
+		{"negative", "-1G", 0, true},
+		{"unknown unit", "1X", 0, true},
+		{"too large", "9999999999T", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBytes(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBytes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
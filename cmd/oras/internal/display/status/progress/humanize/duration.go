@@ -15,7 +15,12 @@ limitations under the License.
 
 package humanize
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // FormatDuration formats a duration into a human-readable string.
 // It rounds the duration to the nearest second, millisecond, or microsecond
@@ -31,3 +36,25 @@ func FormatDuration(d time.Duration) string {
 	}
 	return d.String()
 }
+
+// ParseDuration parses a duration string as [time.ParseDuration] does, with
+// the extra unit "d" for days, e.g. "30d" or "1d12h".
+func ParseDuration(s string) (time.Duration, error) {
+	str := strings.TrimSpace(s)
+	var days time.Duration
+	if i := strings.IndexByte(str, 'd'); i >= 0 {
+		n, err := strconv.ParseUint(str[:i], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		if str = str[i+1:]; str == "" {
+			return days, nil
+		}
+	}
+	d, err := time.ParseDuration(str)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return days + d, nil
+}
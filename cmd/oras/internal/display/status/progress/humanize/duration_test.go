@@ -120,3 +120,32 @@ func TestFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"1d12h", 36 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{" 2h ", 2 * time.Hour, false},
+		{"", 0, true},
+		{"d", 0, true},
+		{"-1d", 0, true},
+		{"-1h", 0, true},
+		{"1w", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseDuration(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
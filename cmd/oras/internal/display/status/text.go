@@ -52,6 +52,16 @@ func (ph *TextPushHandler) OnFileLoading(name string) error {
 	return ph.printer.PrintVerbose("Preparing", name)
 }
 
+// OnMediaTypeDetected is called when the media type of a file is detected.
+func (ph *TextPushHandler) OnMediaTypeDetected(name string, mediaType string) error {
+	return ph.printer.Println(PushPromptDetected, name, mediaType)
+}
+
+// OnFileExcluded is called when a file is excluded by the filters.
+func (ph *TextPushHandler) OnFileExcluded(name string) error {
+	return ph.printer.PrintVerbose(PushPromptExcluded, name)
+}
+
 // OnEmptyArtifact is called when an empty artifact is being uploaded.
 func (ph *TextPushHandler) OnEmptyArtifact() error {
 	return ph.printer.Println("Uploading empty artifact")
@@ -128,6 +138,11 @@ func (ph *TextPullHandler) OnNodeSkipped(desc ocispec.Descriptor) error {
 	return ph.printer.PrintStatus(desc, PullPromptSkipped)
 }
 
+// OnNodeExists implements PullHandler.
+func (ph *TextPullHandler) OnNodeExists(desc ocispec.Descriptor) error {
+	return ph.printer.PrintStatus(desc, PullPromptExists)
+}
+
 // NewTextPullHandler returns a new handler for pull command.
 func NewTextPullHandler(printer *output.Printer) PullHandler {
 	return &TextPullHandler{
@@ -150,6 +150,15 @@ func TestTextPullHandler_OnNodeSkipped(t *testing.T) {
 	validatePrinted(t, "Skipped     0b442c23c1dd oci-image")
 }
 
+func TestTextPullHandler_OnNodeExists(t *testing.T) {
+	builder.Reset()
+	ph := NewTextPullHandler(printer)
+	if ph.OnNodeExists(mockFetcher.OciImage) != nil {
+		t.Error("OnNodeExists() should not return an error")
+	}
+	validatePrinted(t, "Exists      0b442c23c1dd oci-image")
+}
+
 func TestTextPushHandler_OnCopySkipped(t *testing.T) {
 	builder.Reset()
 	ph := NewTextPushHandler(printer, mockFetcher.Fetcher)
@@ -177,6 +186,24 @@ func TestTextPushHandler_OnFileLoading(t *testing.T) {
 	validatePrinted(t, "")
 }
 
+func TestTextPushHandler_OnMediaTypeDetected(t *testing.T) {
+	builder.Reset()
+	ph := NewTextPushHandler(printer, mockFetcher.Fetcher)
+	if ph.OnMediaTypeDetected("sbom.json", "application/spdx+json") != nil {
+		t.Error("OnMediaTypeDetected() should not return an error")
+	}
+	validatePrinted(t, "Detected  sbom.json application/spdx+json")
+}
+
+func TestTextPushHandler_OnFileExcluded(t *testing.T) {
+	builder.Reset()
+	ph := NewTextPushHandler(printer, mockFetcher.Fetcher)
+	if ph.OnFileExcluded("bin/app.pdb") != nil {
+		t.Error("OnFileExcluded() should not return an error")
+	}
+	validatePrinted(t, "")
+}
+
 func TestTextPushHandler_PostCopy(t *testing.T) {
 	builder.Reset()
 	ph := NewTextPushHandler(printer, mockFetcher.Fetcher)
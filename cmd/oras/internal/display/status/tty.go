@@ -17,6 +17,7 @@ package status
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"sync"
 
@@ -51,6 +52,17 @@ func (ph *TTYPushHandler) OnFileLoading(_ string) error {
 	return nil
 }
 
+// OnMediaTypeDetected is called when the media type of a file is detected.
+func (ph *TTYPushHandler) OnMediaTypeDetected(name string, mediaType string) error {
+	_, err := fmt.Fprintln(ph.tty, PushPromptDetected, name, mediaType)
+	return err
+}
+
+// OnFileExcluded is called when a file is excluded by the filters.
+func (ph *TTYPushHandler) OnFileExcluded(_ string) error {
+	return nil
+}
+
 // OnEmptyArtifact is called when no file is loaded for an artifact push.
 func (ph *TTYPushHandler) OnEmptyArtifact() error {
 	return nil
@@ -142,6 +154,11 @@ func (ph *TTYPullHandler) OnNodeSkipped(desc ocispec.Descriptor) error {
 	return ph.tracked.Report(desc, progress.StateSkipped)
 }
 
+// OnNodeExists implements PullHandler.
+func (ph *TTYPullHandler) OnNodeExists(desc ocispec.Descriptor) error {
+	return ph.tracked.Report(desc, progress.StateExists)
+}
+
 // TrackTarget returns a tracked target.
 func (ph *TTYPullHandler) TrackTarget(gt oras.GraphTarget) (oras.GraphTarget, StopTrackTargetFunc, error) {
 	prompt := map[progress.State]string{
@@ -149,6 +166,7 @@ func (ph *TTYPullHandler) TrackTarget(gt oras.GraphTarget) (oras.GraphTarget, St
 		progress.StateTransmitting: PullPromptDownloading,
 		progress.StateTransmitted:  PullPromptPulled,
 		progress.StateSkipped:      PullPromptSkipped,
+		progress.StateExists:       PullPromptExists,
 		progress.StateRestored:     PullPromptRestored,
 	}
 	tracked, err := track.NewTarget(gt, prompt, ph.tty)
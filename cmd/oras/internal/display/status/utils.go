@@ -29,6 +29,7 @@ const (
 	PullPromptSkipped     = "Skipped    "
 	PullPromptRestored    = "Restored   "
 	PullPromptDownloaded  = "Downloaded "
+	PullPromptExists      = "Exists     "
 )
 
 // Prompts for push/attach events.
@@ -37,6 +38,8 @@ const (
 	PushPromptUploading = "Uploading"
 	PushPromptSkipped   = "Skipped  "
 	PushPromptExists    = "Exists   "
+	PushPromptDetected  = "Detected "
+	PushPromptExcluded  = "Excluded "
 )
 
 // Prompts for cp events.
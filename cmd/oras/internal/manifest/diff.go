@@ -0,0 +1,280 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	fetcher "oras.land/oras-go/v2/content"
+	"oras.land/oras/internal/descriptor"
+)
+
+// Types of the changes.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Change is a change of a field from one manifest to another.
+type Change[T any] struct {
+	From T `json:"from"`
+	To   T `json:"to"`
+}
+
+// AnnotationChange is a change of an annotation.
+type AnnotationChange struct {
+	Type string  `json:"type"`
+	Key  string  `json:"key"`
+	From *string `json:"from,omitempty"`
+	To   *string `json:"to,omitempty"`
+}
+
+// LayerChange is a change of a layer, where the layers are matched by their
+// titles, or by their digests if untitled.
+type LayerChange struct {
+	Type  string              `json:"type"`
+	Title string              `json:"title,omitempty"`
+	From  *ocispec.Descriptor `json:"from,omitempty"`
+	To    *ocispec.Descriptor `json:"to,omitempty"`
+}
+
+// PlatformChange is a change of a child manifest of an index, where the child
+// manifests are matched by their platforms. Diff is the difference between
+// the changed child manifests if compared.
+type PlatformChange struct {
+	Type     string              `json:"type"`
+	Platform string              `json:"platform"`
+	From     *ocispec.Descriptor `json:"from,omitempty"`
+	To       *ocispec.Descriptor `json:"to,omitempty"`
+	Diff     *Diff               `json:"diff,omitempty"`
+}
+
+// Diff is the difference between two manifests or indexes.
+type Diff struct {
+	MediaType    *Change[string]              `json:"mediaType,omitempty"`
+	ArtifactType *Change[string]              `json:"artifactType,omitempty"`
+	Config       *Change[*ocispec.Descriptor] `json:"config,omitempty"`
+	Subject      *Change[*ocispec.Descriptor] `json:"subject,omitempty"`
+	Annotations  []AnnotationChange           `json:"annotations"`
+	Layers       []LayerChange                `json:"layers"`
+	Manifests    []PlatformChange             `json:"manifests"`
+}
+
+// IsEmpty returns true if there is no difference.
+func (d *Diff) IsEmpty() bool {
+	return d.MediaType == nil && d.ArtifactType == nil && d.Config == nil && d.Subject == nil &&
+		len(d.Annotations) == 0 && len(d.Layers) == 0 && len(d.Manifests) == 0
+}
+
+// content contains the compared fields of a manifest or an index.
+type content struct {
+	MediaType    string               `json:"mediaType"`
+	ArtifactType string               `json:"artifactType"`
+	Config       *ocispec.Descriptor  `json:"config"`
+	Layers       []ocispec.Descriptor `json:"layers"`
+	Blobs        []ocispec.Descriptor `json:"blobs"`
+	Manifests    []ocispec.Descriptor `json:"manifests"`
+	Subject      *ocispec.Descriptor  `json:"subject"`
+	Annotations  map[string]string    `json:"annotations"`
+}
+
+// Compare compares two manifests or indexes in JSON format. The blobs of
+// artifact manifests are compared as layers.
+func Compare(from, to []byte) (*Diff, error) {
+	var fromContent, toContent content
+	if err := json.Unmarshal(from, &fromContent); err != nil {
+		return nil, fmt.Errorf("failed to parse the first manifest: %w", err)
+	}
+	if err := json.Unmarshal(to, &toContent); err != nil {
+		return nil, fmt.Errorf("failed to parse the second manifest: %w", err)
+	}
+	diff := &Diff{
+		Annotations: compareAnnotations(fromContent.Annotations, toContent.Annotations),
+		Layers:      compareLayers(append(fromContent.Layers, fromContent.Blobs...), append(toContent.Layers, toContent.Blobs...)),
+		Manifests:   compareManifests(fromContent.Manifests, toContent.Manifests),
+	}
+	if fromContent.MediaType != toContent.MediaType {
+		diff.MediaType = &Change[string]{From: fromContent.MediaType, To: toContent.MediaType}
+	}
+	if fromContent.ArtifactType != toContent.ArtifactType {
+		diff.ArtifactType = &Change[string]{From: fromContent.ArtifactType, To: toContent.ArtifactType}
+	}
+	if digestOf(fromContent.Config) != digestOf(toContent.Config) {
+		diff.Config = &Change[*ocispec.Descriptor]{From: fromContent.Config, To: toContent.Config}
+	}
+	if digestOf(fromContent.Subject) != digestOf(toContent.Subject) {
+		diff.Subject = &Change[*ocispec.Descriptor]{From: fromContent.Subject, To: toContent.Subject}
+	}
+	return diff, nil
+}
+
+// CompareGraphs compares two manifests or indexes in JSON format like Compare,
+// and compares the changed child manifests recursively, which are fetched
+// from fromFetcher and toFetcher respectively. The child manifests are not
+// compared if either fetcher is nil.
+func CompareGraphs(ctx context.Context, from []byte, fromFetcher fetcher.Fetcher, to []byte, toFetcher fetcher.Fetcher) (*Diff, error) {
+	diff, err := Compare(from, to)
+	if err != nil || fromFetcher == nil || toFetcher == nil {
+		return diff, err
+	}
+	for i := range diff.Manifests {
+		change := &diff.Manifests[i]
+		if change.Type != ChangeChanged || !descriptor.IsManifest(*change.From) || !descriptor.IsManifest(*change.To) {
+			continue
+		}
+		fromChild, err := fetcher.FetchAll(ctx, fromFetcher, *change.From)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the %s manifest %s: %w", change.Platform, change.From.Digest, err)
+		}
+		toChild, err := fetcher.FetchAll(ctx, toFetcher, *change.To)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the %s manifest %s: %w", change.Platform, change.To.Digest, err)
+		}
+		if change.Diff, err = CompareGraphs(ctx, fromChild, fromFetcher, toChild, toFetcher); err != nil {
+			return nil, err
+		}
+	}
+	return diff, nil
+}
+
+// digestOf returns the digest of the optional descriptor.
+func digestOf(desc *ocispec.Descriptor) digest.Digest {
+	if desc == nil {
+		return ""
+	}
+	return desc.Digest
+}
+
+// compareAnnotations compares the annotations sorted by keys.
+func compareAnnotations(from, to map[string]string) []AnnotationChange {
+	changes := []AnnotationChange{}
+	keys := slices.Collect(maps.Keys(from))
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+		switch {
+		case !inFrom:
+			changes = append(changes, AnnotationChange{Type: ChangeAdded, Key: key, To: &toValue})
+		case !inTo:
+			changes = append(changes, AnnotationChange{Type: ChangeRemoved, Key: key, From: &fromValue})
+		case fromValue != toValue:
+			changes = append(changes, AnnotationChange{Type: ChangeChanged, Key: key, From: &fromValue, To: &toValue})
+		}
+	}
+	return changes
+}
+
+// compareLayers compares the layers matched by their titles, or by their
+// digests if untitled. The changes are ordered by the new layers, followed by
+// the removed layers.
+func compareLayers(from, to []ocispec.Descriptor) []LayerChange {
+	key := func(layer ocispec.Descriptor) string {
+		if title := layer.Annotations[ocispec.AnnotationTitle]; title != "" {
+			return "title:" + title
+		}
+		return "digest:" + layer.Digest.String()
+	}
+	return compareBy(from, to, key, func(changeType string, from, to *ocispec.Descriptor) LayerChange {
+		layer := to
+		if layer == nil {
+			layer = from
+		}
+		return LayerChange{
+			Type:  changeType,
+			Title: layer.Annotations[ocispec.AnnotationTitle],
+			From:  from,
+			To:    to,
+		}
+	})
+}
+
+// compareManifests compares the child manifests matched by their platforms.
+// The changes are ordered by the new manifests, followed by the removed
+// manifests.
+func compareManifests(from, to []ocispec.Descriptor) []PlatformChange {
+	return compareBy(from, to, platformOf, func(changeType string, from, to *ocispec.Descriptor) PlatformChange {
+		manifest := to
+		if manifest == nil {
+			manifest = from
+		}
+		return PlatformChange{
+			Type:     changeType,
+			Platform: platformOf(*manifest),
+			From:     from,
+			To:       to,
+		}
+	})
+}
+
+// platformOf returns the platform of the child manifest for matching.
+func platformOf(manifest ocispec.Descriptor) string {
+	if platform := descriptor.PlatformString(manifest); platform != "" {
+		return platform
+	}
+	return "<unknown>"
+}
+
+// compareBy compares the descriptors matched by the keys, where the
+// descriptors of the same key are matched in order.
+func compareBy[T any](from, to []ocispec.Descriptor, key func(ocispec.Descriptor) string, newChange func(changeType string, from, to *ocispec.Descriptor) T) []T {
+	unmatched := make(map[string][]int)
+	for i, desc := range from {
+		k := key(desc)
+		unmatched[k] = append(unmatched[k], i)
+	}
+	matched := make([]bool, len(from))
+	changes := []T{}
+	for i := range to {
+		toDesc := &to[i]
+		k := key(*toDesc)
+		candidates := unmatched[k]
+		if len(candidates) == 0 {
+			changes = append(changes, newChange(ChangeAdded, nil, toDesc))
+			continue
+		}
+		j := candidates[0]
+		unmatched[k] = candidates[1:]
+		matched[j] = true
+		if fromDesc := &from[j]; !sameContent(*fromDesc, *toDesc) {
+			changes = append(changes, newChange(ChangeChanged, fromDesc, toDesc))
+		}
+	}
+	for i := range from {
+		if !matched[i] {
+			changes = append(changes, newChange(ChangeRemoved, &from[i], nil))
+		}
+	}
+	return changes
+}
+
+// sameContent returns true if the descriptors describe the same content of the
+// same media type.
+func sameContent(a, b ocispec.Descriptor) bool {
+	return a.Digest == b.Digest && a.Size == b.Size && a.MediaType == b.MediaType
+}
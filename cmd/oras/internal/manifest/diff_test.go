@@ -0,0 +1,218 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	fetcher "oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func newLayer(content, title string) ocispec.Descriptor {
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    digest.FromString(content),
+		Size:      int64(len(content)),
+	}
+	if title != "" {
+		desc.Annotations = map[string]string{ocispec.AnnotationTitle: title}
+	}
+	return desc
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestCompare_manifest(t *testing.T) {
+	config := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageConfig, Digest: digest.FromString("config"), Size: 6}
+	newConfig := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageConfig, Digest: digest.FromString("new config"), Size: 10}
+	subject := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("subject"), Size: 7}
+	kept := newLayer("kept", "kept.txt")
+	oldFoo := newLayer("foo", "foo.txt")
+	newFoo := newLayer("new foo", "foo.txt")
+	removed := newLayer("bar", "bar.txt")
+	untitled := newLayer("untitled", "")
+	added := newLayer("baz", "")
+
+	from := mustMarshal(t, ocispec.Manifest{
+		MediaType:   ocispec.MediaTypeImageManifest,
+		Config:      config,
+		Layers:      []ocispec.Descriptor{kept, oldFoo, removed, untitled},
+		Annotations: map[string]string{"kept": "v", "changed": "old", "removed": "v"},
+	})
+	to := mustMarshal(t, ocispec.Manifest{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "test/artifact",
+		Config:       newConfig,
+		Layers:       []ocispec.Descriptor{untitled, newFoo, kept, added},
+		Subject:      &subject,
+		Annotations:  map[string]string{"kept": "v", "changed": "new", "added": "v"},
+	})
+	got, err := Compare(from, to)
+	if err != nil {
+		t.Fatal("Compare() error =", err)
+	}
+	old, changedTo, v := "old", "new", "v"
+	want := &Diff{
+		ArtifactType: &Change[string]{From: "", To: "test/artifact"},
+		Config:       &Change[*ocispec.Descriptor]{From: &config, To: &newConfig},
+		Subject:      &Change[*ocispec.Descriptor]{From: nil, To: &subject},
+		Annotations: []AnnotationChange{
+			{Type: ChangeAdded, Key: "added", To: &v},
+			{Type: ChangeChanged, Key: "changed", From: &old, To: &changedTo},
+			{Type: ChangeRemoved, Key: "removed", From: &v},
+		},
+		Layers: []LayerChange{
+			{Type: ChangeChanged, Title: "foo.txt", From: &oldFoo, To: &newFoo},
+			{Type: ChangeAdded, To: &added},
+			{Type: ChangeRemoved, Title: "bar.txt", From: &removed},
+		},
+		Manifests: []PlatformChange{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare() = %s, want %s", mustMarshal(t, got), mustMarshal(t, want))
+	}
+	if got.IsEmpty() {
+		t.Error("Diff.IsEmpty() = true, want false")
+	}
+
+	got, err = Compare(from, from)
+	if err != nil {
+		t.Fatal("Compare() error =", err)
+	}
+	if !got.IsEmpty() {
+		t.Errorf("Diff.IsEmpty() = false, want true for %s", mustMarshal(t, got))
+	}
+}
+
+func TestCompare_index(t *testing.T) {
+	newManifest := func(content string, platform *ocispec.Platform) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromString(content),
+			Size:      int64(len(content)),
+			Platform:  platform,
+		}
+	}
+	amd64 := &ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	arm64 := &ocispec.Platform{OS: "linux", Architecture: "arm64"}
+	armV7 := &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}
+	oldAMD64 := newManifest("amd64", amd64)
+	newAMD64 := newManifest("new amd64", amd64)
+	keptARM64 := newManifest("arm64", arm64)
+	removedARMV7 := newManifest("arm/v7", armV7)
+	attestation := newManifest("attestation", nil)
+
+	from := mustMarshal(t, ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{oldAMD64, keptARM64, removedARMV7},
+	})
+	to := mustMarshal(t, ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{newAMD64, keptARM64, attestation},
+	})
+	got, err := Compare(from, to)
+	if err != nil {
+		t.Fatal("Compare() error =", err)
+	}
+	want := []PlatformChange{
+		{Type: ChangeChanged, Platform: "linux/amd64", From: &oldAMD64, To: &newAMD64},
+		{Type: ChangeAdded, Platform: "<unknown>", To: &attestation},
+		{Type: ChangeRemoved, Platform: "linux/arm/v7", From: &removedARMV7},
+	}
+	if !reflect.DeepEqual(got.Manifests, want) {
+		t.Errorf("Compare() manifests = %s, want %s", mustMarshal(t, got.Manifests), mustMarshal(t, want))
+	}
+	if got.MediaType != nil || got.Config != nil || len(got.Layers) != 0 {
+		t.Errorf("Compare() = %s, want only manifest changes", mustMarshal(t, got))
+	}
+}
+
+func TestCompare_invalid(t *testing.T) {
+	if _, err := Compare([]byte("{}"), []byte("invalid")); err == nil {
+		t.Error("Compare() error = nil, want error")
+	}
+}
+
+func TestCompareGraphs(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	push := func(mediaType string, data []byte, platform *ocispec.Platform) ocispec.Descriptor {
+		desc := fetcher.NewDescriptorFromBytes(mediaType, data)
+		if err := store.Push(ctx, desc, bytes.NewReader(data)); err != nil {
+			t.Fatal("Push() error =", err)
+		}
+		desc.Platform = platform
+		return desc
+	}
+	amd64 := &ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	oldApp := newLayer("old", "app")
+	newApp := newLayer("new", "app")
+	oldManifest := push(ocispec.MediaTypeImageManifest, mustMarshal(t, ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Layers:    []ocispec.Descriptor{oldApp},
+	}), amd64)
+	newManifest := push(ocispec.MediaTypeImageManifest, mustMarshal(t, ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Layers:    []ocispec.Descriptor{newApp},
+	}), amd64)
+	from := mustMarshal(t, ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{oldManifest},
+	})
+	to := mustMarshal(t, ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{newManifest},
+	})
+
+	got, err := CompareGraphs(ctx, from, store, to, store)
+	if err != nil {
+		t.Fatal("CompareGraphs() error =", err)
+	}
+	if len(got.Manifests) != 1 || got.Manifests[0].Diff == nil {
+		t.Fatalf("CompareGraphs() manifests = %s, want the child manifests compared", mustMarshal(t, got.Manifests))
+	}
+	want := []LayerChange{{Type: ChangeChanged, Title: "app", From: &oldApp, To: &newApp}}
+	if layers := got.Manifests[0].Diff.Layers; !reflect.DeepEqual(layers, want) {
+		t.Errorf("CompareGraphs() child layers = %s, want %s", mustMarshal(t, layers), mustMarshal(t, want))
+	}
+
+	// child manifests are not compared without fetchers
+	if got, err = CompareGraphs(ctx, from, nil, to, store); err != nil {
+		t.Fatal("CompareGraphs() error =", err)
+	}
+	if len(got.Manifests) != 1 || got.Manifests[0].Diff != nil {
+		t.Errorf("CompareGraphs() manifests = %s, want the child manifests not compared", mustMarshal(t, got.Manifests))
+	}
+
+	// missing child manifests fail the comparison
+	if _, err := CompareGraphs(ctx, from, memory.New(), to, store); err == nil {
+		t.Error("CompareGraphs() error = nil, want error")
+	}
+}
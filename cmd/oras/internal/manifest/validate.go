@@ -0,0 +1,428 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/docker"
+)
+
+// Severities of the findings.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Finding is an issue found when validating a manifest.
+type Finding struct {
+	Severity string `json:"severity"`
+	// Field is the path of the field where the issue is found, such as
+	// "layers[0].digest". It is empty if the issue is about the whole
+	// manifest.
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// DescriptorResolver resolves the content referenced by a descriptor in a
+// manifest.
+type DescriptorResolver func(ctx context.Context, desc ocispec.Descriptor) (ocispec.Descriptor, error)
+
+var (
+	// mediaTypeRegexp matches the media types complying with RFC 6838.
+	mediaTypeRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}/[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}$`)
+	// reverseDomainRegexp matches the annotation keys in the reverse domain
+	// notation.
+	reverseDomainRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+(\.[^.]+)+$`)
+)
+
+// predefinedAnnotations are the annotation keys defined by the image-spec.
+var predefinedAnnotations = map[string]bool{
+	ocispec.AnnotationCreated:         true,
+	ocispec.AnnotationAuthors:         true,
+	ocispec.AnnotationURL:             true,
+	ocispec.AnnotationDocumentation:   true,
+	ocispec.AnnotationSource:          true,
+	ocispec.AnnotationVersion:         true,
+	ocispec.AnnotationRevision:        true,
+	ocispec.AnnotationVendor:          true,
+	ocispec.AnnotationLicenses:        true,
+	ocispec.AnnotationRefName:         true,
+	ocispec.AnnotationTitle:           true,
+	ocispec.AnnotationDescription:     true,
+	ocispec.AnnotationBaseImageDigest: true,
+	ocispec.AnnotationBaseImageName:   true,
+}
+
+// legacyAnnotations are the annotation keys defined by the withdrawn artifact
+// spec, mapped to their image-spec equivalents.
+var legacyAnnotations = map[string]string{
+	"org.opencontainers.artifact.created":     ocispec.AnnotationCreated,
+	"org.opencontainers.artifact.description": ocispec.AnnotationDescription,
+}
+
+// Validate validates a manifest or an index in JSON format against the OCI
+// image-spec. mediaType is the expected media type of the content, or empty if
+// unknown. If resolve is not nil, the referenced contents are resolved to
+// check their existence and sizes.
+// The returned error is non-nil only if the referenced contents fail to be
+// resolved; issues in the content are reported as findings.
+func Validate(ctx context.Context, content []byte, mediaType string, resolve DescriptorResolver) ([]Finding, error) {
+	v := &validator{
+		ctx:     ctx,
+		resolve: resolve,
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(content, &fields); err != nil || fields == nil {
+		v.report(SeverityError, "", "content is not a JSON object")
+		return v.findings, nil
+	}
+
+	if raw, ok := fields["schemaVersion"]; !ok {
+		v.report(SeverityError, "schemaVersion", "is required")
+	} else {
+		var version int
+		if v.decode("schemaVersion", raw, &version, "an integer") && version != 2 {
+			v.report(SeverityError, "schemaVersion", "must be 2, got %d", version)
+		}
+	}
+
+	var fieldMediaType string
+	if raw, ok := fields["mediaType"]; ok {
+		v.decode("mediaType", raw, &fieldMediaType, "a string")
+	}
+	switch {
+	case fieldMediaType == "":
+		v.report(SeverityWarning, "mediaType", "should be set to the media type of the manifest")
+	case mediaType != "" && fieldMediaType != mediaType:
+		v.report(SeverityError, "mediaType", "%q does not match the media type %q of the manifest", fieldMediaType, mediaType)
+	}
+	if mediaType == "" {
+		mediaType = fieldMediaType
+	}
+
+	switch mediaType {
+	case ocispec.MediaTypeImageManifest:
+		v.validateManifest(fields, true)
+	case docker.MediaTypeManifest:
+		v.validateManifest(fields, false)
+	case ocispec.MediaTypeImageIndex:
+		v.validateIndex(fields, true)
+	case docker.MediaTypeManifestList:
+		v.validateIndex(fields, false)
+	case "":
+		// infer the type of the manifest from its properties
+		if _, ok := fields["manifests"]; ok {
+			v.validateIndex(fields, true)
+		} else {
+			v.validateManifest(fields, true)
+		}
+	default:
+		v.report(SeverityWarning, "mediaType", "%q is not a known manifest media type, only the subject and the annotations are validated", mediaType)
+		v.validateArtifactType(fields)
+		v.validateSubject(fields)
+		v.validateAnnotations(fields)
+	}
+	return v.findings, v.err
+}
+
+// Count returns the number of the findings of the given severity.
+func Count(findings []Finding, severity string) int {
+	var count int
+	for _, finding := range findings {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// validator collects the findings of a validation.
+type validator struct {
+	ctx      context.Context
+	resolve  DescriptorResolver
+	findings []Finding
+	err      error
+}
+
+// report adds a finding.
+func (v *validator) report(severity, field, format string, a ...any) {
+	v.findings = append(v.findings, Finding{
+		Severity: severity,
+		Field:    field,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+// decode decodes the raw value of a field, and reports an error if the value
+// is not of the expected type.
+func (v *validator) decode(field string, raw json.RawMessage, value any, typeName string) bool {
+	if err := json.Unmarshal(raw, value); err != nil {
+		v.report(SeverityError, field, "must be %s", typeName)
+		return false
+	}
+	return true
+}
+
+// validateManifest validates the properties of an image manifest. The
+// artifact guidance only applies to OCI manifests.
+func (v *validator) validateManifest(fields map[string]json.RawMessage, oci bool) {
+	var config *ocispec.Descriptor
+	if raw, ok := fields["config"]; ok {
+		config = v.validateDescriptor("config", raw, SeverityError)
+	} else {
+		v.report(SeverityError, "config", "is required")
+	}
+	if raw, ok := fields["layers"]; ok {
+		var layers []json.RawMessage
+		if v.decode("layers", raw, &layers, "an array of descriptors") {
+			if len(layers) == 0 && oci {
+				v.report(SeverityWarning, "layers", "should have at least one entry for portability, use the empty descriptor if there is no blob")
+			}
+			for i, layer := range layers {
+				v.validateDescriptor(fmt.Sprintf("layers[%d]", i), layer, SeverityError)
+			}
+		}
+	} else {
+		v.report(SeverityError, "layers", "is required")
+	}
+	if _, ok := fields["manifests"]; ok {
+		v.report(SeverityWarning, "manifests", "is not a property of a manifest")
+	}
+	artifactType := v.validateArtifactType(fields)
+	v.validateSubject(fields)
+	v.validateAnnotations(fields)
+	if oci && config != nil {
+		v.validateArtifactGuidance(artifactType, config)
+	}
+}
+
+// validateIndex validates the properties of an image index.
+func (v *validator) validateIndex(fields map[string]json.RawMessage, oci bool) {
+	if raw, ok := fields["manifests"]; ok {
+		var manifests []json.RawMessage
+		if v.decode("manifests", raw, &manifests, "an array of descriptors") {
+			for i, manifest := range manifests {
+				field := fmt.Sprintf("manifests[%d]", i)
+				desc := v.validateDescriptor(field, manifest, SeverityError)
+				if desc != nil && desc.MediaType != "" && !descriptor.IsManifest(*desc) {
+					v.report(SeverityWarning, field+".mediaType", "%q is not the media type of a manifest or an index", desc.MediaType)
+				}
+			}
+		}
+	} else {
+		v.report(SeverityError, "manifests", "is required")
+	}
+	for _, field := range []string{"config", "layers"} {
+		if _, ok := fields[field]; ok {
+			v.report(SeverityWarning, field, "is not a property of an index")
+		}
+	}
+	if oci {
+		v.validateArtifactType(fields)
+	}
+	v.validateSubject(fields)
+	v.validateAnnotations(fields)
+}
+
+// validateArtifactGuidance validates a manifest against the artifact guidance
+// of the image-spec v1.1, and notes the artifacts following the guidance of
+// v1.0.
+func (v *validator) validateArtifactGuidance(artifactType string, config *ocispec.Descriptor) {
+	switch {
+	case artifactType == "" && config.MediaType == ocispec.MediaTypeEmptyJSON:
+		v.report(SeverityError, "artifactType", "must be set when the config is the empty descriptor")
+	case artifactType == "" && config.MediaType != ocispec.MediaTypeImageConfig && mediaTypeRegexp.MatchString(config.MediaType):
+		v.report(SeverityInfo, "config.mediaType", "is used as the artifact type following the image-spec v1.0 guidance, consider setting artifactType")
+	}
+}
+
+// validateArtifactType validates the artifact type of a manifest and returns
+// it.
+func (v *validator) validateArtifactType(fields map[string]json.RawMessage) string {
+	raw, ok := fields["artifactType"]
+	if !ok {
+		return ""
+	}
+	var artifactType string
+	if !v.decode("artifactType", raw, &artifactType, "a string") {
+		return ""
+	}
+	if !mediaTypeRegexp.MatchString(artifactType) {
+		v.report(SeverityError, "artifactType", "%q is not a valid media type", artifactType)
+	}
+	return artifactType
+}
+
+// validateSubject validates the subject of a manifest. A subject not found is
+// not an error since it may be pushed afterwards.
+func (v *validator) validateSubject(fields map[string]json.RawMessage) {
+	raw, ok := fields["subject"]
+	if !ok {
+		return
+	}
+	subject := v.validateDescriptor("subject", raw, SeverityWarning)
+	if subject != nil && subject.MediaType != "" && !descriptor.IsManifest(*subject) {
+		v.report(SeverityWarning, "subject.mediaType", "%q is not the media type of a manifest or an index", subject.MediaType)
+	}
+}
+
+// validateAnnotations validates the annotations of a manifest.
+func (v *validator) validateAnnotations(fields map[string]json.RawMessage) {
+	raw, ok := fields["annotations"]
+	if !ok {
+		return
+	}
+	var annotations map[string]string
+	if v.decode("annotations", raw, &annotations, "a map of strings") {
+		v.validateAnnotationMap("annotations", annotations)
+	}
+}
+
+// validateAnnotationMap validates the keys and the well-known values of the
+// annotations.
+func (v *validator) validateAnnotationMap(field string, annotations map[string]string) {
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		keyField := fmt.Sprintf("%s[%q]", field, key)
+		if equivalent, ok := legacyAnnotations[key]; ok {
+			v.report(SeverityInfo, keyField, "is defined by the withdrawn artifact spec, use %q instead", equivalent)
+			continue
+		}
+		switch {
+		case key == "":
+			v.report(SeverityError, keyField, "key must not be empty")
+		case strings.HasPrefix(key, "org.opencontainers.") && !predefinedAnnotations[key]:
+			v.report(SeverityWarning, keyField, "key uses the reserved namespace org.opencontainers but is not defined by the image-spec")
+		case !reverseDomainRegexp.MatchString(key):
+			v.report(SeverityWarning, keyField, "key should use the reverse domain notation, such as com.example.key")
+		}
+		if key == ocispec.AnnotationCreated {
+			if _, err := time.Parse(time.RFC3339, annotations[key]); err != nil {
+				v.report(SeverityError, keyField, "value %q is not a date and time as defined by RFC 3339", annotations[key])
+			}
+		}
+	}
+}
+
+// validateDescriptor validates a descriptor and the content it references, and
+// returns the parsed descriptor, or nil if it is malformed. missingSeverity is
+// the severity to report if the referenced content is not found.
+func (v *validator) validateDescriptor(field string, raw json.RawMessage, missingSeverity string) *ocispec.Descriptor {
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &properties); err != nil || properties == nil {
+		v.report(SeverityError, field, "must be a descriptor")
+		return nil
+	}
+	var desc ocispec.Descriptor
+	if err := json.Unmarshal(raw, &desc); err != nil {
+		v.report(SeverityError, field, "is not a valid descriptor: %v", err)
+		return nil
+	}
+
+	switch {
+	case desc.MediaType == "":
+		v.report(SeverityError, field+".mediaType", "is required")
+	case !mediaTypeRegexp.MatchString(desc.MediaType):
+		v.report(SeverityError, field+".mediaType", "%q is not a valid media type", desc.MediaType)
+	}
+	if desc.ArtifactType != "" && !mediaTypeRegexp.MatchString(desc.ArtifactType) {
+		v.report(SeverityError, field+".artifactType", "%q is not a valid media type", desc.ArtifactType)
+	}
+
+	validDigest := false
+	if desc.Digest == "" {
+		v.report(SeverityError, field+".digest", "is required")
+	} else if err := desc.Digest.Validate(); err != nil {
+		if errors.Is(err, digest.ErrDigestUnsupported) {
+			v.report(SeverityWarning, field+".digest", "uses an unsupported digest algorithm %q", desc.Digest.Algorithm())
+		} else {
+			v.report(SeverityError, field+".digest", "%q is not a valid digest: %v", desc.Digest, err)
+		}
+	} else {
+		validDigest = true
+	}
+
+	if _, ok := properties["size"]; !ok {
+		v.report(SeverityError, field+".size", "is required")
+	} else if desc.Size < 0 {
+		v.report(SeverityError, field+".size", "must not be negative, got %d", desc.Size)
+	}
+
+	for i, rawURL := range desc.URLs {
+		urlField := fmt.Sprintf("%s.urls[%d]", field, i)
+		u, err := url.Parse(rawURL)
+		if err != nil || !u.IsAbs() {
+			v.report(SeverityError, urlField, "%q is not an absolute URI", rawURL)
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			v.report(SeverityWarning, urlField, "%q should use the http or https scheme", rawURL)
+		}
+	}
+
+	if desc.Data != nil && validDigest {
+		if got := desc.Digest.Algorithm().FromBytes(desc.Data); got != desc.Digest {
+			v.report(SeverityError, field+".data", "has digest %s which does not match the digest of the descriptor", got)
+		}
+		if size := int64(len(desc.Data)); size != desc.Size {
+			v.report(SeverityError, field+".data", "has size %d which does not match the size %d of the descriptor", size, desc.Size)
+		}
+	}
+
+	if desc.MediaType == ocispec.MediaTypeEmptyJSON &&
+		(desc.Digest != ocispec.DescriptorEmptyJSON.Digest || desc.Size != ocispec.DescriptorEmptyJSON.Size) {
+		v.report(SeverityError, field, "is not a valid empty descriptor, expecting digest %s and size %d", ocispec.DescriptorEmptyJSON.Digest, ocispec.DescriptorEmptyJSON.Size)
+	}
+
+	if desc.Platform != nil {
+		if desc.Platform.OS == "" {
+			v.report(SeverityError, field+".platform.os", "is required")
+		}
+		if desc.Platform.Architecture == "" {
+			v.report(SeverityError, field+".platform.architecture", "is required")
+		}
+	}
+	v.validateAnnotationMap(field+".annotations", desc.Annotations)
+
+	if v.resolve != nil && v.err == nil && validDigest {
+		if len(desc.URLs) > 0 {
+			// non-distributable content may not be in the repository
+			missingSeverity = SeverityWarning
+		}
+		got, err := v.resolve(v.ctx, desc)
+		switch {
+		case errors.Is(err, errdef.ErrNotFound):
+			v.report(missingSeverity, field, "references %s which is not found", desc.Digest)
+		case err != nil:
+			v.err = err
+		case got.Size != desc.Size:
+			v.report(SeverityError, field+".size", "is %d but the referenced content has size %d", desc.Size, got.Size)
+		}
+	}
+	return &desc
+}
@@ -0,0 +1,193 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+)
+
+const (
+	validConfig = `{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2}`
+	validLayer  = `{"mediaType":"application/vnd.oci.image.layer.v1.tar","digest":"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae","size":3,"annotations":{"org.opencontainers.image.title":"foo.txt"}}`
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		mediaType string
+		want      []Finding
+	}{
+		{
+			name:    "valid image manifest",
+			content: `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":` + validConfig + `,"layers":[` + validLayer + `]}`,
+		},
+		{
+			name:    "valid artifact manifest",
+			content: `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","artifactType":"application/vnd.example+type","config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2,"data":"e30="},"layers":[` + validLayer + `],"annotations":{"org.opencontainers.image.created":"2024-01-02T03:04:05Z","com.example.key":"value"}}`,
+		},
+		{
+			name:    "valid index",
+			content: `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae","size":3,"platform":{"os":"linux","architecture":"amd64"}}]}`,
+		},
+		{
+			name:    "not a JSON object",
+			content: `[]`,
+			want:    []Finding{{SeverityError, "", "content is not a JSON object"}},
+		},
+		{
+			name:      "schema errors",
+			content:   `{"schemaVersion":1,"mediaType":"application/vnd.oci.image.index.v1+json","layers":"foo"}`,
+			mediaType: ocispec.MediaTypeImageManifest,
+			want: []Finding{
+				{SeverityError, "schemaVersion", "must be 2, got 1"},
+				{SeverityError, "mediaType", `"application/vnd.oci.image.index.v1+json" does not match the media type "application/vnd.oci.image.manifest.v1+json" of the manifest`},
+				{SeverityError, "config", "is required"},
+				{SeverityError, "layers", "must be an array of descriptors"},
+			},
+		},
+		{
+			name:    "missing media type and inferred index",
+			content: `{"schemaVersion":2,"manifests":[],"config":` + validConfig + `}`,
+			want: []Finding{
+				{SeverityWarning, "mediaType", "should be set to the media type of the manifest"},
+				{SeverityWarning, "config", "is not a property of an index"},
+			},
+		},
+		{
+			name:    "invalid descriptor fields",
+			content: `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"invalid","digest":"sha256:abc","size":-1},"layers":[{"digest":"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae","urls":["ftp://example.com/foo","foo"],"data":"YmFy"}]}`,
+			want: []Finding{
+				{SeverityError, "config.mediaType", `"invalid" is not a valid media type`},
+				{SeverityError, "config.digest", `"sha256:abc" is not a valid digest: invalid checksum digest length`},
+				{SeverityError, "config.size", "must not be negative, got -1"},
+				{SeverityError, "layers[0].mediaType", "is required"},
+				{SeverityError, "layers[0].size", "is required"},
+				{SeverityWarning, "layers[0].urls[0]", `"ftp://example.com/foo" should use the http or https scheme`},
+				{SeverityError, "layers[0].urls[1]", `"foo" is not an absolute URI`},
+				{SeverityError, "layers[0].data", "has digest sha256:fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9 which does not match the digest of the descriptor"},
+				{SeverityError, "layers[0].data", "has size 3 which does not match the size 0 of the descriptor"},
+			},
+		},
+		{
+			name:    "artifact guidance",
+			content: `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae","size":3},"layers":[]}`,
+			want: []Finding{
+				{SeverityError, "config", "is not a valid empty descriptor, expecting digest sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a and size 2"},
+				{SeverityWarning, "layers", "should have at least one entry for portability, use the empty descriptor if there is no blob"},
+				{SeverityError, "artifactType", "must be set when the config is the empty descriptor"},
+			},
+		},
+		{
+			name:    "v1.0 artifact",
+			content: `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.example+type","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[` + validLayer + `]}`,
+			want: []Finding{
+				{SeverityInfo, "config.mediaType", "is used as the artifact type following the image-spec v1.0 guidance, consider setting artifactType"},
+			},
+		},
+		{
+			name:    "annotations and subject",
+			content: `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","artifactType":"invalid","manifests":[{"mediaType":"application/vnd.oci.image.layer.v1.tar","digest":"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae","size":3,"platform":{"os":"linux"}}],"subject":` + validLayer + `,"annotations":{"org.opencontainers.image.created":"yesterday","org.opencontainers.foo":"bar","foo":"bar","org.opencontainers.artifact.created":"2024-01-02T03:04:05Z"}}`,
+			want: []Finding{
+				{SeverityError, "manifests[0].platform.architecture", "is required"},
+				{SeverityWarning, "manifests[0].mediaType", `"application/vnd.oci.image.layer.v1.tar" is not the media type of a manifest or an index`},
+				{SeverityError, "artifactType", `"invalid" is not a valid media type`},
+				{SeverityWarning, "subject.mediaType", `"application/vnd.oci.image.layer.v1.tar" is not the media type of a manifest or an index`},
+				{SeverityWarning, "annotations[\"foo\"]", "key should use the reverse domain notation, such as com.example.key"},
+				{SeverityInfo, "annotations[\"org.opencontainers.artifact.created\"]", `is defined by the withdrawn artifact spec, use "org.opencontainers.image.created" instead`},
+				{SeverityWarning, "annotations[\"org.opencontainers.foo\"]", "key uses the reserved namespace org.opencontainers but is not defined by the image-spec"},
+				{SeverityError, "annotations[\"org.opencontainers.image.created\"]", `value "yesterday" is not a date and time as defined by RFC 3339`},
+			},
+		},
+		{
+			name:      "unknown media type",
+			content:   `{"schemaVersion":2,"mediaType":"application/vnd.example+json","annotations":{"foo":"bar"}}`,
+			mediaType: "application/vnd.example+json",
+			want: []Finding{
+				{SeverityWarning, "mediaType", `"application/vnd.example+json" is not a known manifest media type, only the subject and the annotations are validated`},
+				{SeverityWarning, "annotations[\"foo\"]", "key should use the reverse domain notation, such as com.example.key"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Validate(context.Background(), []byte(tt.content), tt.mediaType, nil)
+			if err != nil {
+				t.Fatal("Validate() error =", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_resolve(t *testing.T) {
+	config := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageConfig, Digest: digest.FromString("config"), Size: 6}
+	layer := newLayer("foo", "foo.txt")
+	missing := newLayer("missing", "missing.txt")
+	foreign := newLayer("foreign", "foreign.tar")
+	foreign.URLs = []string{"https://example.com/foreign.tar"}
+	subject := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("subject"), Size: 7}
+	content := mustMarshal(t, ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ocispec.Descriptor{layer, missing, foreign},
+		Subject:   &subject,
+	})
+	// the size of the layer in the storage differs from the descriptor
+	stored := map[digest.Digest]int64{
+		config.Digest: config.Size,
+		layer.Digest:  layer.Size + 1,
+	}
+	resolve := func(_ context.Context, desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+		size, ok := stored[desc.Digest]
+		if !ok {
+			return ocispec.Descriptor{}, errdef.ErrNotFound
+		}
+		return ocispec.Descriptor{MediaType: desc.MediaType, Digest: desc.Digest, Size: size}, nil
+	}
+	got, err := Validate(context.Background(), content, ocispec.MediaTypeImageManifest, resolve)
+	if err != nil {
+		t.Fatal("Validate() error =", err)
+	}
+	want := []Finding{
+		{SeverityError, "layers[0].size", "is 3 but the referenced content has size 4"},
+		{SeverityError, "layers[1]", "references " + missing.Digest.String() + " which is not found"},
+		{SeverityWarning, "layers[2]", "references " + foreign.Digest.String() + " which is not found"},
+		{SeverityWarning, "subject", "references " + subject.Digest.String() + " which is not found"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+
+	wantErr := errors.New("failed to resolve")
+	resolve = func(_ context.Context, _ ocispec.Descriptor) (ocispec.Descriptor, error) {
+		return ocispec.Descriptor{}, wantErr
+	}
+	if _, err := Validate(context.Background(), content, ocispec.MediaTypeImageManifest, resolve); !errors.Is(err, wantErr) {
+		t.Errorf("Validate() error = %v, wantErr %v", err, wantErr)
+	}
+}
@@ -49,6 +49,13 @@ func (target *BinaryTarget) EnableDistributionSpecFlag() {
 	target.To.EnableDistributionSpecFlag()
 }
 
+// EnableFileSource allows the source and the destination to be local manifest
+// files.
+func (target *BinaryTarget) EnableFileSource() {
+	target.From.EnableFileSource()
+	target.To.EnableFileSource()
+}
+
 // ApplyFlags applies flags to a command flag set fs.
 func (target *BinaryTarget) ApplyFlags(fs *pflag.FlagSet) {
 	target.From.setFlagDetails("from", "source")
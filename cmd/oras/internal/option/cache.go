@@ -16,26 +16,183 @@ limitations under the License.
 package option
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"time"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"oras.land/oras-go/v2"
-	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/internal/cache"
+	"oras.land/oras/internal/graph"
 )
 
+// cacheEnv is the environment variable specifying the cache root.
+const cacheEnv = "ORAS_CACHE"
+
 type Cache struct {
-	Root string
+	Root          string
+	Offline       bool
+	TTL           time.Duration
+	PopulateCache bool
+
+	ttlFlag       string
+	applyOffline  bool
+	applyPopulate bool
+}
+
+// EnableOfflineFlags set offline and cache TTL flags as applicable.
+func (opts *Cache) EnableOfflineFlags() {
+	opts.applyOffline = true
+}
+
+// EnablePopulateFlag set the flag populating the cache with pushed content as
+// applicable.
+func (opts *Cache) EnablePopulateFlag() {
+	opts.applyPopulate = true
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Cache) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&opts.Root, "cache", "", "", "`path` of the local cache directory, overriding the environment variable "+cacheEnv)
+	if opts.applyOffline {
+		fs.BoolVarP(&opts.Offline, "offline", "", false, "resolve tags and fetch content only from the local cache without accessing the registry")
+		fs.StringVarP(&opts.ttlFlag, "cache-ttl", "", "", "resolve tags from the local cache if they were resolved within the `duration` (e.g. 10m, 1d)")
+	}
+	if opts.applyPopulate {
+		fs.BoolVarP(&opts.PopulateCache, "populate-cache", "", false, "store the pushed content in the local cache so that it is not downloaded again")
+	}
+}
+
+// Parse parses the offline, cache TTL and populate cache flags.
+func (opts *Cache) Parse(cmd *cobra.Command) error {
+	if opts.ttlFlag != "" {
+		ttl, err := humanize.ParseDuration(opts.ttlFlag)
+		if err != nil {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("invalid value for `--cache-ttl`: %w", err),
+				Recommendation: "specify a duration such as 30s, 10m, 1h or 1d",
+			}
+		}
+		opts.TTL = ttl
+	}
+	if (opts.Offline || opts.TTL > 0 || opts.PopulateCache) && !opts.enabled() {
+		flag := "--cache-ttl"
+		switch {
+		case opts.Offline:
+			flag = "--offline"
+		case opts.PopulateCache:
+			flag = "--populate-cache"
+		}
+		return &oerrors.Error{
+			Err:            fmt.Errorf("`%s` requires the local cache", flag),
+			Recommendation: "specify the cache directory via `--cache` or the environment variable " + cacheEnv,
+		}
+	}
+	return nil
+}
+
+// enabled loads the cache root from the environment if not specified by the
+// flag, and returns true if caching is enabled.
+func (opts *Cache) enabled() bool {
+	if opts.Root == "" {
+		opts.Root = os.Getenv(cacheEnv)
+	}
+	return opts.Root != ""
+}
+
+// LoadRoot loads the cache root and returns an error if caching is not
+// enabled.
+func (opts *Cache) LoadRoot() error {
+	if !opts.enabled() {
+		return &oerrors.Error{
+			Err:            errors.New("cache is not enabled"),
+			Recommendation: "specify the cache directory via `--cache` or the environment variable " + cacheEnv,
+		}
+	}
+	return nil
 }
 
 // CachedTarget gets the target storage with caching if cache root is specified.
 func (opts *Cache) CachedTarget(src oras.ReadOnlyTarget) (oras.ReadOnlyTarget, error) {
-	opts.Root = os.Getenv("ORAS_CACHE")
-	if opts.Root != "" {
-		ociStore, err := oci.New(opts.Root)
+	if opts.enabled() {
+		store, err := cache.NewStore(opts.Root)
 		if err != nil {
 			return nil, err
 		}
-		return cache.New(src, ociStore), nil
+		return cache.New(src, store), nil
 	}
 	return src, nil
 }
+
+// CachedRepositoryTarget gets the target storage of the repository with
+// caching if cache root is specified. Resolved tags are recorded in the cache,
+// and resolved from the cache within the cache TTL or in offline mode.
+func (opts *Cache) CachedRepositoryTarget(src oras.ReadOnlyTarget, repository string) (oras.ReadOnlyTarget, error) {
+	if !opts.enabled() {
+		return src, nil
+	}
+	return cache.NewWithTags(src, opts.Root, cache.TagOptions{
+		Repository: repository,
+		TTL:        opts.TTL,
+		Offline:    opts.Offline,
+	})
+}
+
+// CheckCacheMiss converts a cache miss error in offline mode into an error
+// with recommendation.
+func (opts *Cache) CheckCacheMiss(err error) error {
+	if !errors.Is(err, cache.ErrCacheMiss) {
+		return err
+	}
+	return &oerrors.Error{
+		Err:            err,
+		Recommendation: "run the command without `--offline` to fetch it from the registry into the cache",
+	}
+}
+
+// CachedGraphTarget gets the graph target with caching if cache root is
+// specified and src is a repository. Other targets, such as OCI image
+// layouts, are local and returned as is.
+func (opts *Cache) CachedGraphTarget(src oras.ReadOnlyGraphTarget) (oras.ReadOnlyGraphTarget, error) {
+	repo, ok := src.(cache.ReadOnlyRepository)
+	if !ok || !opts.enabled() {
+		return src, nil
+	}
+	store, err := cache.NewStore(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	return cache.NewGraph(repo, store), nil
+}
+
+// Populate copies the graph rooted at root from src into the cache if
+// requested by the populate cache flag, so that pushed content is not
+// downloaded again. The subject of root is not copied.
+func (opts *Cache) Populate(ctx context.Context, src content.ReadOnlyStorage, root ocispec.Descriptor) error {
+	if !opts.PopulateCache || !opts.enabled() {
+		return nil
+	}
+	store, err := cache.NewStore(opts.Root)
+	if err != nil {
+		return err
+	}
+	copyOpts := oras.DefaultCopyGraphOptions
+	copyOpts.FindSuccessors = func(ctx context.Context, fetcher content.Fetcher, node ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		successors, _, config, err := graph.Successors(ctx, fetcher, node)
+		if err != nil {
+			return nil, err
+		}
+		if config != nil {
+			successors = append(successors, *config)
+		}
+		return successors, nil
+	}
+	return oras.CopyGraph(ctx, src, store, root, copyOpts)
+}
@@ -16,12 +16,21 @@ limitations under the License.
 package option
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/memory"
-	"oras.land/oras-go/v2/content/oci"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/internal/cache"
 )
 
@@ -32,11 +41,11 @@ func TestCache_CachedTarget(t *testing.T) {
 	t.Setenv("ORAS_CACHE", tempDir)
 	opts := Cache{}
 
-	ociStore, err := oci.New(tempDir)
+	store, err := cache.NewStore(tempDir)
 	if err != nil {
-		t.Fatal("error calling oci.New(), error =", err)
+		t.Fatal("error calling cache.NewStore(), error =", err)
 	}
-	want := cache.New(mockTarget, ociStore)
+	want := cache.New(mockTarget, store)
 
 	got, err := opts.CachedTarget(mockTarget)
 	if err != nil {
@@ -59,3 +68,149 @@ func TestCache_CachedTarget_emptyRoot(t *testing.T) {
 		t.Fatalf("Cache.CachedTarget() got %v, want %v", got, mockTarget)
 	}
 }
+
+func TestCache_LoadRoot(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("ORAS_CACHE", tempDir)
+	opts := Cache{}
+	if err := opts.LoadRoot(); err != nil {
+		t.Fatal("Cache.LoadRoot() error =", err)
+	}
+	if opts.Root != tempDir {
+		t.Fatalf("Cache.Root = %v, want %v", opts.Root, tempDir)
+	}
+
+	t.Setenv("ORAS_CACHE", "")
+	opts = Cache{}
+	if err := opts.LoadRoot(); err == nil {
+		t.Fatal("Cache.LoadRoot() error = nil, want error")
+	}
+}
+
+func TestCache_CachedTarget_flag(t *testing.T) {
+	t.Setenv("ORAS_CACHE", t.TempDir())
+	root := t.TempDir()
+	opts := Cache{Root: root}
+	if _, err := opts.CachedTarget(mockTarget); err != nil {
+		t.Fatal("Cache.CachedTarget() error=", err)
+	}
+	if opts.Root != root {
+		t.Fatalf("Cache.Root = %v, want %v", opts.Root, root)
+	}
+}
+
+func TestCache_CachedGraphTarget_local(t *testing.T) {
+	t.Setenv("ORAS_CACHE", t.TempDir())
+	opts := Cache{}
+	src := memory.New()
+	got, err := opts.CachedGraphTarget(src)
+	if err != nil {
+		t.Fatal("Cache.CachedGraphTarget() error=", err)
+	}
+	if got != src {
+		t.Fatalf("Cache.CachedGraphTarget() got %v, want %v", got, src)
+	}
+}
+
+func TestCache_Populate(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	layer := []byte("hello world")
+	layerDesc := content.NewDescriptorFromBytes("test", layer)
+	if err := src.Push(ctx, layerDesc, bytes.NewReader(layer)); err != nil {
+		t.Fatal(err)
+	}
+	subject := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("not pushed"))
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "test/artifact", oras.PackManifestOptions{
+		Subject: &subject,
+		Layers:  []ocispec.Descriptor{layerDesc},
+	})
+	if err != nil {
+		t.Fatal("PackManifest() error =", err)
+	}
+
+	cacheRoot := t.TempDir()
+	opts := Cache{Root: cacheRoot}
+	if err := opts.Populate(ctx, src, root); err != nil {
+		t.Fatal("Cache.Populate() error =", err)
+	}
+	if entries, err := os.ReadDir(cacheRoot); err != nil || len(entries) != 0 {
+		t.Fatalf("cache entries = %v, %v, want none without populating", entries, err)
+	}
+
+	opts.PopulateCache = true
+	if err := opts.Populate(ctx, src, root); err != nil {
+		t.Fatal("Cache.Populate() error =", err)
+	}
+	blobs, err := cache.List(cacheRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[digest.Digest]bool)
+	for _, blob := range blobs {
+		got[blob.Digest] = true
+	}
+	want := map[digest.Digest]bool{
+		root.Digest:                        true,
+		layerDesc.Digest:                   true,
+		ocispec.DescriptorEmptyJSON.Digest: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cached blobs = %v, want %v", got, want)
+	}
+}
+
+func TestCache_Parse(t *testing.T) {
+	t.Setenv("ORAS_CACHE", "")
+	tests := []struct {
+		name    string
+		opts    Cache
+		wantTTL time.Duration
+		wantErr bool
+	}{
+		{"no flags", Cache{}, 0, false},
+		{"ttl", Cache{Root: t.TempDir(), ttlFlag: "1d2h"}, 26 * time.Hour, false},
+		{"offline", Cache{Root: t.TempDir(), Offline: true}, 0, false},
+		{"invalid ttl", Cache{Root: t.TempDir(), ttlFlag: "soon"}, 0, true},
+		{"ttl without cache", Cache{ttlFlag: "10m"}, 0, true},
+		{"offline without cache", Cache{Offline: true}, 0, true},
+		{"populate cache", Cache{Root: t.TempDir(), PopulateCache: true}, 0, false},
+		{"populate cache without cache", Cache{PopulateCache: true}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Parse(&cobra.Command{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Cache.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.opts.TTL != tt.wantTTL {
+				t.Errorf("Cache.TTL = %v, want %v", tt.opts.TTL, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestCache_CachedRepositoryTarget(t *testing.T) {
+	t.Setenv("ORAS_CACHE", "")
+	opts := Cache{}
+	got, err := opts.CachedRepositoryTarget(mockTarget, "localhost:5000/test")
+	if err != nil {
+		t.Fatal("Cache.CachedRepositoryTarget() error =", err)
+	}
+	if !reflect.DeepEqual(got, mockTarget) {
+		t.Fatalf("Cache.CachedRepositoryTarget() got %v, want %v", got, mockTarget)
+	}
+
+	opts = Cache{Root: t.TempDir(), Offline: true}
+	if got, err = opts.CachedRepositoryTarget(mockTarget, "localhost:5000/test"); err != nil {
+		t.Fatal("Cache.CachedRepositoryTarget() error =", err)
+	}
+	_, err = got.Resolve(context.Background(), "latest")
+	if !errors.Is(err, cache.ErrCacheMiss) {
+		t.Fatalf("Resolve() error = %v, want %v", err, cache.ErrCacheMiss)
+	}
+	var cmdErr *oerrors.Error
+	if !errors.As(opts.CheckCacheMiss(err), &cmdErr) || cmdErr.Recommendation == "" {
+		t.Errorf("Cache.CheckCacheMiss() = %v, want error with recommendation", opts.CheckCacheMiss(err))
+	}
+}
@@ -30,6 +30,8 @@ import (
 	"oras.land/oras-go/v2/content"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/fileref"
+	"oras.land/oras/internal/mediatype"
+	"oras.land/oras/internal/pathfilter"
 )
 
 // Pre-defined annotation keys for annotation file
@@ -50,6 +52,17 @@ type Packer struct {
 	ManifestExportPath     string
 	PathValidationDisabled bool
 	AnnotationFilePath     string
+	DetectMediaType        bool
+	MediaTypeMappingPath   string
+	// MediaTypeDetector is set when media type detection is enabled.
+	MediaTypeDetector *mediatype.Detector
+	IncludePatterns   []string
+	ExcludePatterns   []string
+	// FileFilter filters the files matched by globs and the contents of
+	// directories. It is nil if there is no pattern.
+	FileFilter *pathfilter.Filter
+	// ExcludedFiles are the files matched by globs but excluded by FileFilter.
+	ExcludedFiles []string
 
 	FileRefs []string
 }
@@ -61,6 +74,10 @@ func (opts *Packer) ApplyFlags(fs *pflag.FlagSet) {
 	fs.StringVarP(&opts.ManifestExportPath, "export-manifest", "", "", "`path` of the pushed manifest")
 	fs.StringVarP(&opts.AnnotationFilePath, "annotation-file", "", "", "path of the annotation file")
 	fs.BoolVarP(&opts.PathValidationDisabled, "disable-path-validation", "", false, "skip path validation")
+	fs.BoolVarP(&opts.DetectMediaType, "detect-media-type", "", false, "detect the media types of files specified without a type from their content and extensions")
+	fs.StringArrayVarP(&opts.IncludePatterns, "include", "", nil, "only push the files matching the glob `pattern` when expanding globs and directories")
+	fs.StringArrayVarP(&opts.ExcludePatterns, "exclude", "", nil, "exclude the files matching the glob `pattern` when expanding globs and directories. Directories are also filtered by the patterns in their "+pathfilter.IgnoreFileName+" files")
+	fs.StringVarP(&opts.MediaTypeMappingPath, "media-type-mapping", "", "", "`path` of the JSON file mapping file extensions to media types for --detect-media-type")
 }
 
 // ExportManifest saves the pushed manifest to a local file.
@@ -92,9 +109,89 @@ func (opts *Packer) Parse(cmd *cobra.Command) error {
 			return fmt.Errorf("%w: %v", errPathValidation, strings.Join(failedPaths, ", "))
 		}
 	}
+	if err := opts.parseMediaTypeDetection(); err != nil {
+		return err
+	}
+	if err := opts.parseFileFilter(); err != nil {
+		return err
+	}
 	return opts.parseAnnotations(cmd)
 }
 
+// parseFileFilter loads the file filter and expands the glob file references.
+func (opts *Packer) parseFileFilter() error {
+	if len(opts.IncludePatterns) > 0 || len(opts.ExcludePatterns) > 0 {
+		var err error
+		if opts.FileFilter, err = pathfilter.New(opts.IncludePatterns, opts.ExcludePatterns); err != nil {
+			return err
+		}
+	}
+
+	fileRefs := make([]string, 0, len(opts.FileRefs))
+	for _, fileRef := range opts.FileRefs {
+		path, mediaType, err := fileref.Parse(fileRef, "")
+		if err != nil {
+			return err
+		}
+		if !isGlob(path) {
+			fileRefs = append(fileRefs, fileRef)
+			continue
+		}
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return fmt.Errorf("invalid glob pattern %q: %w", path, err)
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("no file matches the glob pattern %q", path)
+		}
+		for _, match := range matches {
+			fi, err := os.Stat(match)
+			if err != nil {
+				return err
+			}
+			if opts.FileFilter.Excluded(match, fi.IsDir()) {
+				opts.ExcludedFiles = append(opts.ExcludedFiles, filepath.ToSlash(match))
+				continue
+			}
+			fileRefs = append(fileRefs, match+":"+mediaType)
+		}
+	}
+	opts.FileRefs = fileRefs
+	return nil
+}
+
+// isGlob returns true if the path is a glob pattern rather than an existing
+// file.
+func isGlob(path string) bool {
+	if !strings.ContainsAny(path, "*?[") {
+		return false
+	}
+	_, err := os.Lstat(path)
+	return err != nil
+}
+
+// parseMediaTypeDetection loads the media type detector if enabled.
+func (opts *Packer) parseMediaTypeDetection() error {
+	if !opts.DetectMediaType {
+		if opts.MediaTypeMappingPath != "" {
+			return &oerrors.Error{
+				Err:            errors.New("`--media-type-mapping` can only be used with `--detect-media-type`"),
+				Recommendation: "enable media type detection via `--detect-media-type`",
+			}
+		}
+		return nil
+	}
+	var mapping map[string]string
+	if opts.MediaTypeMappingPath != "" {
+		var err error
+		if mapping, err = mediatype.LoadMapping(opts.MediaTypeMappingPath); err != nil {
+			return err
+		}
+	}
+	opts.MediaTypeDetector = mediatype.NewDetector(mapping)
+	return nil
+}
+
 // parseAnnotations loads the manifest annotation map.
 func (opts *Packer) parseAnnotations(cmd *cobra.Command) error {
 	if opts.AnnotationFilePath != "" && len(opts.ManifestAnnotations) != 0 {
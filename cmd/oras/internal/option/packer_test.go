@@ -185,3 +185,67 @@ func TestPacker_decodeJSON(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestPacker_parseMediaTypeDetection(t *testing.T) {
+	opts := Packer{}
+	if err := opts.parseMediaTypeDetection(); err != nil || opts.MediaTypeDetector != nil {
+		t.Fatalf("parseMediaTypeDetection() = %v, detector %v, want no detector", err, opts.MediaTypeDetector)
+	}
+
+	opts = Packer{MediaTypeMappingPath: "mapping.json"}
+	if err := opts.parseMediaTypeDetection(); err == nil {
+		t.Fatal("parseMediaTypeDetection() error = nil, want error for mapping without detection")
+	}
+
+	mappingPath := filepath.Join(t.TempDir(), "mapping.json")
+	if err := os.WriteFile(mappingPath, []byte(`{".md": "text/markdown"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	opts = Packer{DetectMediaType: true, MediaTypeMappingPath: mappingPath}
+	if err := opts.parseMediaTypeDetection(); err != nil {
+		t.Fatal("parseMediaTypeDetection() error =", err)
+	}
+	if got := opts.MediaTypeDetector.DetectByName("README.md"); got != "text/markdown" {
+		t.Errorf("detected media type = %q, want %q", got, "text/markdown")
+	}
+
+	opts = Packer{DetectMediaType: true, MediaTypeMappingPath: filepath.Join(t.TempDir(), "missing.json")}
+	if err := opts.parseMediaTypeDetection(); err == nil {
+		t.Fatal("parseMediaTypeDetection() error = nil, want error for missing mapping file")
+	}
+}
+
+func TestPacker_parseFileFilter(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"app", "app.pdb", "lib.so", "notes.md"} {
+		if err := os.WriteFile(name, []byte(name), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// ignore files are only applied to the packed directories
+	if err := os.WriteFile(".orasignore", []byte("lib.so\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := Packer{
+		FileRefs:        []string{"a*:application/x-bin", "lib.so", "*.md"},
+		ExcludePatterns: []string{"*.pdb", "*.md"},
+	}
+	if err := opts.parseFileFilter(); err != nil {
+		t.Fatal("parseFileFilter() error =", err)
+	}
+	if want := []string{"app:application/x-bin", "lib.so"}; !reflect.DeepEqual(opts.FileRefs, want) {
+		t.Errorf("FileRefs = %v, want %v", opts.FileRefs, want)
+	}
+	if want := []string{"app.pdb", "notes.md"}; !reflect.DeepEqual(opts.ExcludedFiles, want) {
+		t.Errorf("ExcludedFiles = %v, want %v", opts.ExcludedFiles, want)
+	}
+	if !opts.FileFilter.Excluded("bin/app.pdb", false) {
+		t.Error("FileFilter does not exclude bin/app.pdb")
+	}
+
+	opts = Packer{FileRefs: []string{"*.exe"}}
+	if err := opts.parseFileFilter(); err == nil {
+		t.Error("parseFileFilter() error = nil, want error for unmatched glob")
+	}
+}
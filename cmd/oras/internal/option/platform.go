@@ -45,12 +45,22 @@ func (opts *Platform) Parse(*cobra.Command) error {
 	if opts.platform == "" {
 		return nil
 	}
+	p, err := ParsePlatform(opts.platform)
+	if err != nil {
+		return err
+	}
+	opts.Platform = p
+	return nil
+}
 
+// ParsePlatform parses a platform string in the form of
+// `os[/arch][/variant][:os_version]` to an oci platform type.
+func ParsePlatform(platform string) (*ocispec.Platform, error) {
 	// OS[/Arch[/Variant]][:OSVersion]
 	// If Arch is not provided, will use GOARCH instead
 	var platformStr string
 	var p ocispec.Platform
-	platformStr, p.OSVersion, _ = strings.Cut(opts.platform, ":")
+	platformStr, p.OSVersion, _ = strings.Cut(platform, ":")
 	parts := strings.Split(platformStr, "/")
 	switch len(parts) {
 	case 3:
@@ -61,17 +71,16 @@ func (opts *Platform) Parse(*cobra.Command) error {
 	case 1:
 		p.Architecture = runtime.GOARCH
 	default:
-		return fmt.Errorf("failed to parse platform %q: expected format os[/arch[/variant]]", opts.platform)
+		return nil, fmt.Errorf("failed to parse platform %q: expected format os[/arch[/variant]]", platform)
 	}
 	p.OS = parts[0]
 	if p.OS == "" {
-		return fmt.Errorf("invalid platform: OS cannot be empty")
+		return nil, fmt.Errorf("invalid platform: OS cannot be empty")
 	}
 	if p.Architecture == "" {
-		return fmt.Errorf("invalid platform: Architecture cannot be empty")
+		return nil, fmt.Errorf("invalid platform: Architecture cannot be empty")
 	}
-	opts.Platform = &p
-	return nil
+	return &p, nil
 }
 
 // ArtifactPlatform option struct.
@@ -23,8 +23,10 @@ import (
 	"io/fs"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -43,6 +45,7 @@ import (
 const (
 	TargetTypeRemote    = "registry"
 	TargetTypeOCILayout = "oci-layout"
+	TargetTypeFile      = "file"
 )
 
 // Target struct contains flags and arguments specifying one registry or image
@@ -62,6 +65,43 @@ type Target struct {
 
 	prefix      string
 	description string
+	fileEnabled bool
+}
+
+// EnableFileSource allows the raw reference to be a path to a local manifest
+// file, or "-" to read the manifest from stdin. The path must be absolute or
+// start with "./" or "../" to be told apart from a reference.
+func (target *Target) EnableFileSource() {
+	target.fileEnabled = true
+}
+
+// isFileSource returns true if the raw reference is a local manifest file.
+func (target *Target) isFileSource() bool {
+	if !target.fileEnabled {
+		return false
+	}
+	raw := target.RawReference
+	if raw == "-" || filepath.IsAbs(raw) {
+		return true
+	}
+	for _, prefix := range []string{".", ".."} {
+		if strings.HasPrefix(raw, prefix+"/") || strings.HasPrefix(raw, prefix+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseErrorRecommendation returns the recommendation for a raw reference
+// failing to parse, which may be a local manifest file without the explicit
+// form.
+func (target *Target) parseErrorRecommendation() string {
+	if target.fileEnabled {
+		if info, err := os.Stat(target.RawReference); err == nil && info.Mode().IsRegular() {
+			return fmt.Sprintf("Please specify the path of the local file as %q", "./"+filepath.ToSlash(target.RawReference))
+		}
+	}
+	return "Please make sure the provided reference is in the form of <registry>/<repo>[:tag|@digest]"
 }
 
 // GetDisplayReference returns full printable reference.
@@ -69,6 +109,15 @@ func (target *Target) GetDisplayReference() string {
 	return fmt.Sprintf("[%s] %s", target.Type, target.RawReference)
 }
 
+// GetDigestReference returns the reference to the content described by desc
+// in the target, or the file path if the target is a local file.
+func (target *Target) GetDigestReference(desc ocispec.Descriptor) string {
+	if target.Type == TargetTypeFile {
+		return target.Path
+	}
+	return target.Path + "@" + desc.Digest.String()
+}
+
 // setFlagDetails set directional flag prefix and description details
 func (target *Target) setFlagDetails(prefix, description string) {
 	if prefix != "" {
@@ -112,13 +161,17 @@ func (target *Target) Parse(cmd *cobra.Command) error {
 		target.Type = TargetTypeOCILayout
 		target.Reference = target.RawReference
 		return nil
+	case target.isFileSource():
+		target.Type = TargetTypeFile
+		target.Path = target.RawReference
+		return nil
 	default:
 		target.Type = TargetTypeRemote
 		if ref, err := registry.ParseReference(target.RawReference); err != nil {
 			return &oerrors.Error{
 				OperationType:  oerrors.OperationTypeParseArtifactReference,
 				Err:            fmt.Errorf("%q: %w", target.RawReference, err),
-				Recommendation: "Please make sure the provided reference is in the form of <registry>/<repo>[:tag|@digest]",
+				Recommendation: target.parseErrorRecommendation(),
 			}
 		} else {
 			target.Reference = ref.Reference
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -121,6 +123,49 @@ func TestTarget_Parse_remote_err(t *testing.T) {
 	}
 }
 
+func TestTarget_Parse_file(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "manifest.json")
+	if err := os.WriteFile(path, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	for _, raw := range []string{path, "-", "./manifest.json", "../" + filepath.Base(dir) + "/manifest.json"} {
+		opts := Target{RawReference: raw}
+		opts.EnableFileSource()
+		cmd := &cobra.Command{}
+		ApplyFlags(&opts, cmd.Flags())
+		if err := opts.Parse(cmd); err != nil {
+			t.Errorf("Target.Parse() error = %v", err)
+		}
+		if opts.Type != TargetTypeFile || opts.Path != raw {
+			t.Errorf("Target.Parse() failed, got %q %q, want %q %q", opts.Type, opts.Path, TargetTypeFile, raw)
+		}
+	}
+
+	// files are not allowed by default
+	opts := Target{RawReference: path}
+	cmd := &cobra.Command{}
+	ApplyFlags(&opts, cmd.Flags())
+	if err := opts.Parse(cmd); err == nil && opts.Type == TargetTypeFile {
+		t.Errorf("Target.Parse() got %q, want non-file target", opts.Type)
+	}
+
+	// paths without the explicit form are references
+	opts = Target{RawReference: "manifest.json"}
+	opts.EnableFileSource()
+	cmd = &cobra.Command{}
+	ApplyFlags(&opts, cmd.Flags())
+	err := opts.Parse(cmd)
+	var oerr *oerrors.Error
+	if !errors.As(err, &oerr) {
+		t.Fatalf("Target.Parse() error = %v, want %T", err, oerr)
+	}
+	if want := `"./manifest.json"`; !strings.Contains(oerr.Recommendation, want) {
+		t.Errorf("Target.Parse() recommendation = %q, want containing %s", oerr.Recommendation, want)
+	}
+}
+
 func Test_parseOCILayoutReference(t *testing.T) {
 	opts := Target{
 		RawReference: "/test",
@@ -29,6 +29,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/status"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/graph"
@@ -36,6 +37,7 @@ import (
 )
 
 type attachOptions struct {
+	option.Cache
 	option.Common
 	option.Packer
 	option.Target
@@ -65,6 +67,9 @@ Example - Attach file 'hi.txt' to a specific artifact with platform 'linux/amd64
 Example - Push file "hi.txt" with the custom layer media type 'application/vnd.me.hi':
   oras attach --artifact-type doc/example localhost:5000/hello:v1 hi.txt:application/vnd.me.hi
 
+Example - Attach file "sbom.json" with the media type detected from its content:
+  oras attach --artifact-type doc/example --detect-media-type localhost:5000/hello:v1 sbom.json
+
 Example - Attach file "hi.txt" using a specific method for the Referrers API:
   oras attach --artifact-type doc/example --distribution-spec v1.1-referrers-api localhost:5000/hello:v1 hi.txt # via API
   oras attach --artifact-type doc/example --distribution-spec v1.1-referrers-tag localhost:5000/hello:v1 hi.txt # via tag scheme
@@ -126,6 +131,7 @@ Example - Attach file to the manifest tagged 'example.com:v1' in an OCI image la
 	_ = cmd.MarkFlagRequired("artifact-type")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.EnableDistributionSpecFlag()
+	opts.EnablePopulateFlag()
 	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
@@ -157,7 +163,11 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
 	fetchOpts := oras.DefaultResolveOptions
 	fetchOpts.TargetPlatform = opts.Platform.Platform
-	subject, err := oras.Resolve(ctx, dst, opts.Reference, fetchOpts)
+	resolver, err := opts.CachedGraphTarget(dst)
+	if err != nil {
+		return err
+	}
+	subject, err := oras.Resolve(ctx, resolver, opts.Reference, fetchOpts)
 	if err != nil {
 		return fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
 	}
@@ -165,29 +175,65 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	if err != nil {
 		return err
 	}
-	descs, err := loadFiles(ctx, store, opts.Annotations, opts.FileRefs, statusHandler)
+	loadOpts := &loadOptions{
+		detector: opts.MediaTypeDetector,
+		filter:   opts.FileFilter,
+	}
+	defer func() { _ = loadOpts.close() }()
+	for _, name := range opts.ExcludedFiles {
+		if err := statusHandler.OnFileExcluded(name); err != nil {
+			return err
+		}
+	}
+	files, err := parseFileRefs(opts.FileRefs)
+	if err != nil {
+		return err
+	}
+	descs, err := loadFiles(ctx, store, loadOpts, opts.Annotations, files, statusHandler)
 	if err != nil {
 		return err
 	}
 
+	packOpts := oras.PackManifestOptions{
+		Subject:             &subject,
+		ManifestAnnotations: opts.Annotations[option.AnnotationManifest],
+		Layers:              descs,
+	}
+
+	// Attach
+	root, err := attachManifest(ctx, store, dst, opts.artifactType, packOpts, opts.concurrency, statusHandler)
+	if err != nil {
+		return err
+	}
+	if err := opts.Populate(ctx, store, root); err != nil {
+		logger.Warnf("failed to populate the cache: %v", err)
+	}
+	metadataHandler.OnAttached(&opts.Target, root, subject)
+	err = metadataHandler.Render()
+	if err != nil {
+		return err
+	}
+
+	// Export manifest
+	return opts.ExportManifest(ctx, store, root)
+}
+
+// attachManifest packs a manifest referring to the subject in packOpts into
+// store and copies it with its layers from store to dst.
+func attachManifest(ctx context.Context, store content.Storage, dst oras.GraphTarget, artifactType string, packOpts oras.PackManifestOptions, concurrency int, statusHandler status.AttachHandler) (ocispec.Descriptor, error) {
 	// prepare push
 	dst, stopTrack, err := statusHandler.TrackTarget(dst)
 	if err != nil {
-		return err
+		return ocispec.Descriptor{}, err
 	}
 	graphCopyOptions := oras.DefaultCopyGraphOptions
-	graphCopyOptions.Concurrency = opts.concurrency
+	graphCopyOptions.Concurrency = concurrency
 	graphCopyOptions.OnCopySkipped = statusHandler.OnCopySkipped
 	graphCopyOptions.PreCopy = statusHandler.PreCopy
 	graphCopyOptions.PostCopy = statusHandler.PostCopy
 
-	packOpts := oras.PackManifestOptions{
-		Subject:             &subject,
-		ManifestAnnotations: opts.Annotations[option.AnnotationManifest],
-		Layers:              descs,
-	}
 	pack := func() (ocispec.Descriptor, error) {
-		return oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, opts.artifactType, packOpts)
+		return oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, packOpts)
 	}
 
 	copy := func(root ocispec.Descriptor) error {
@@ -208,18 +254,5 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 		err := oras.CopyGraph(ctx, store, dst, root, graphCopyOptions)
 		return oerrors.UnwrapCopyError(err) // we don't need the CopyError information so we unwrap it here
 	}
-
-	// Attach
-	root, err := doPush(dst, stopTrack, pack, copy)
-	if err != nil {
-		return err
-	}
-	metadataHandler.OnAttached(&opts.Target, root, subject)
-	err = metadataHandler.Render()
-	if err != nil {
-		return err
-	}
-
-	// Export manifest
-	return opts.ExportManifest(ctx, store, root)
+	return doPush(dst, stopTrack, pack, copy)
 }
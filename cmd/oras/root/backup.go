@@ -58,6 +58,7 @@ const (
 var errTagListNotSupported = errors.New("the target does not support tag listing")
 
 type backupOptions struct {
+	option.Cache
 	option.Common
 	option.Remote
 	option.Terminal
@@ -108,6 +109,9 @@ Example - Back up from an insecure registry:
 Example - Back up from a registry using plain HTTP (no TLS):
   oras backup --output hello --plain-http localhost:5000/hello:v1
 
+Example - Back up reading the blobs through the local cache:
+  oras backup --output hello --cache ~/.oras/cache localhost:5000/hello:v1
+
 Example - Set custom concurrency level:
   oras backup --output hello --concurrency 6 localhost:5000/hello:v1
 `,
@@ -194,6 +198,10 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 	if err != nil {
 		return fmt.Errorf("failed to prepare repository %s for backup: %w", opts.repository, err)
 	}
+	src, err := opts.CachedGraphTarget(srcRepo)
+	if err != nil {
+		return err
+	}
 	dstOCI, err := oci.New(dstRoot)
 	if err != nil {
 		return fmt.Errorf("failed to prepare OCI store for backup: %w", err)
@@ -242,9 +250,9 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 			}()
 
 			if opts.includeReferrers {
-				return backupTagWithReferrers(ctx, srcRepo, trackedDst, tag, roots[i], extCopyGraphOpts)
+				return backupTagWithReferrers(ctx, src, trackedDst, tag, roots[i], extCopyGraphOpts)
 			}
-			return 0, backupTag(ctx, srcRepo, trackedDst, tag, roots[i], copyGraphOpts)
+			return 0, backupTag(ctx, src, trackedDst, tag, roots[i], copyGraphOpts)
 		}()
 		if err != nil {
 			return fmt.Errorf("failed to back up tag %q from %q to %q: %w", tag, opts.repository, dstRoot, oerrors.UnwrapCopyError(err))
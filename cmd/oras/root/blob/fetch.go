@@ -20,6 +20,7 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
@@ -32,6 +33,7 @@ import (
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/progress"
+	"oras.land/oras/internal/resume"
 )
 
 type fetchBlobOptions struct {
@@ -52,6 +54,10 @@ func fetchCmd() *cobra.Command {
 		Short: "Fetch a blob from a registry or an OCI image layout",
 		Long: `Fetch a blob from a registry or an OCI image layout
 
+Interrupted downloads of large blobs from a registry are resumed on the next
+fetch into the same output file if the registry supports range requests. The
+downloads are tracked in the directory ".oras-partial" next to the output file.
+
 Example - Fetch a blob from registry and save it to a local file:
   oras blob fetch --output blob.tar.gz localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
 
@@ -112,6 +118,12 @@ func fetchBlob(cmd *cobra.Command, opts *fetchBlobOptions) (fetchErr error) {
 
 	if repo, ok := target.(*remote.Repository); ok {
 		target = repo.Blobs()
+		if opts.outputPath != "" && opts.outputPath != "-" {
+			// interrupted downloads are resumed on the next fetch
+			target = resume.New(target)
+			ctx = resume.WithFile(ctx, opts.outputPath)
+			defer resume.Cleanup(filepath.Dir(opts.outputPath))
+		}
 	}
 	src, err := opts.CachedTarget(target)
 	if err != nil {
@@ -0,0 +1,69 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/cache"
+)
+
+type clearOptions struct {
+	option.Cache
+	option.Common
+	option.Format
+}
+
+func clearCmd() *cobra.Command {
+	var opts clearOptions
+	cmd := &cobra.Command{
+		Use:   "clear [flags]",
+		Short: "Remove all the content in the cache",
+		Long: `Remove all the content in the cache
+
+The cache is removed once the blobs being stored into it by other processes
+are stored.
+
+Example - Remove the cache:
+  oras cache clear
+
+Example - Remove the cache and print the result in JSON:
+  oras cache clear --format json
+`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			return opts.LoadRoot()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handler, err := display.NewCacheClearHandler(opts.Printer, opts.Format)
+			if err != nil {
+				return err
+			}
+			if err := cache.Clear(cmd.Context(), opts.Root); err != nil {
+				return err
+			}
+			return handler.OnCleared(opts.Root)
+		},
+	}
+
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
@@ -0,0 +1,48 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache [command]",
+		Short: "Manage the local cache",
+		Long: `Manage the local cache
+
+The cache is enabled by specifying the cache directory via the flag --cache or
+the environment variable ORAS_CACHE. Blobs are read through the cache by pull,
+cp, backup, discover and the fetch commands, and pushed blobs are stored in the
+cache by push and attach. The last access time of each blob is updated when it
+is fetched from the cache, so that the least recently used blobs are evicted
+first on pruning.
+
+The cache can be shared by concurrent processes. A blob downloaded by several
+processes at the same time is stored into the cache once, by the process
+finishing the download first.
+`,
+	}
+
+	cmd.AddCommand(
+		listCmd(),
+		duCmd(),
+		pruneCmd(),
+		clearCmd(),
+	)
+	return cmd
+}
@@ -0,0 +1,62 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+type duOptions struct {
+	option.Cache
+	option.Common
+	option.Format
+}
+
+func duCmd() *cobra.Command {
+	var opts duOptions
+	cmd := &cobra.Command{
+		Use:   "du [flags]",
+		Short: "Show the disk usage of the cache",
+		Long: `Show the disk usage of the cache
+
+Example - Show the total size of the blobs in the cache:
+  oras cache du
+
+Example - Show the total size of the blobs in the cache in JSON format:
+  oras cache du --format json
+`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			return opts.LoadRoot()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handler, err := display.NewCacheUsageHandler(opts.Printer, opts.Format, opts.Root)
+			if err != nil {
+				return err
+			}
+			return listBlobs(opts.Root, handler)
+		},
+	}
+
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
@@ -0,0 +1,88 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/cache"
+)
+
+type listOptions struct {
+	option.Cache
+	option.Common
+	option.Format
+}
+
+func listCmd() *cobra.Command {
+	var opts listOptions
+	cmd := &cobra.Command{
+		Use:   "ls [flags]",
+		Short: "List the blobs in the cache",
+		Long: `List the blobs in the cache with their sizes and last access times, from the least recently used
+
+Example - List the blobs in the cache:
+  oras cache ls
+
+Example - List the blobs in the cache in JSON format:
+  oras cache ls --format json
+`,
+		Args:    cobra.NoArgs,
+		Aliases: []string{"list"},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			return opts.LoadRoot()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handler, err := display.NewCacheListHandler(opts.Printer, opts.Format, opts.Root)
+			if err != nil {
+				return err
+			}
+			return listBlobs(opts.Root, handler)
+		},
+	}
+
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+// listBlobs lists the blobs in the cache at root to the handler.
+func listBlobs(root string, handler metadata.CacheListHandler) error {
+	blobs, err := cache.List(root)
+	if err != nil {
+		return err
+	}
+	for _, blob := range blobs {
+		if err := handler.OnBlobListed(descriptorOf(blob), blob.LastAccess); err != nil {
+			return err
+		}
+	}
+	return handler.Render()
+}
+
+// descriptorOf returns the descriptor of the blob in the cache.
+func descriptorOf(blob cache.Blob) ocispec.Descriptor {
+	return ocispec.Descriptor{
+		Digest: blob.Digest,
+		Size:   blob.Size,
+	}
+}
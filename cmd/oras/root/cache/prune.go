@@ -0,0 +1,137 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/cache"
+)
+
+type pruneOptions struct {
+	option.Cache
+	option.Common
+	option.Format
+
+	olderThanFlag string
+	maxSizeFlag   string
+	olderThan     time.Duration
+	maxSize       int64
+	dryRun        bool
+}
+
+func pruneCmd() *cobra.Command {
+	var opts pruneOptions
+	cmd := &cobra.Command{
+		Use:   "prune [flags] {--older-than <duration> | --max-size <size>}",
+		Short: "Remove blobs from the cache",
+		Long: `Remove blobs from the cache
+
+Blobs not accessed within the duration specified by --older-than are removed.
+If --max-size is specified, the least recently used blobs are removed until the
+cache fits in the size. Blobs being stored by other processes are removed once
+stored.
+
+Example - Remove the blobs not accessed in the last 30 days:
+  oras cache prune --older-than 30d
+
+Example - Remove the least recently used blobs until the cache is within 20 GiB:
+  oras cache prune --max-size 20G
+
+Example - Show the blobs to be removed without removing them:
+  oras cache prune --older-than 30d --max-size 20G --dry-run
+
+Example - Remove the blobs not accessed in the last 30 days and print the result in JSON:
+  oras cache prune --older-than 30d --format json
+`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.olderThanFlag == "" && opts.maxSizeFlag == "" {
+				return &oerrors.Error{
+					Err:            errors.New("no blobs are selected to prune"),
+					Recommendation: "specify `--older-than` or `--max-size`",
+				}
+			}
+			var err error
+			opts.maxSize = -1
+			if opts.olderThanFlag != "" {
+				if opts.olderThan, err = humanize.ParseDuration(opts.olderThanFlag); err != nil {
+					return err
+				}
+			}
+			if opts.maxSizeFlag != "" {
+				if opts.maxSize, err = humanize.ParseBytes(opts.maxSizeFlag); err != nil {
+					return err
+				}
+			}
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			return opts.LoadRoot()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handler, err := display.NewCachePruneHandler(opts.Printer, opts.Format, opts.Root, opts.dryRun)
+			if err != nil {
+				return err
+			}
+			return prune(cmd.Context(), &opts, handler)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.olderThanFlag, "older-than", "", "", "remove blobs not accessed within the `duration` (e.g. 30d, 12h)")
+	cmd.Flags().StringVarP(&opts.maxSizeFlag, "max-size", "", "", "remove the least recently used blobs until the cache is within the `size` (e.g. 20G)")
+	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "", false, "show the blobs to be removed without removing them")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+// prune removes the selected blobs from the cache and reports them to the
+// handler.
+func prune(ctx context.Context, opts *pruneOptions, handler metadata.CachePruneHandler) error {
+	blobs, err := cache.List(opts.Root)
+	if err != nil {
+		return err
+	}
+	var before time.Time
+	if opts.olderThanFlag != "" {
+		before = time.Now().Add(-opts.olderThan)
+	}
+	for _, blob := range cache.SelectEvictions(blobs, before, opts.maxSize) {
+		if !opts.dryRun {
+			if err := cache.Remove(ctx, opts.Root, blob); err != nil {
+				return err
+			}
+		}
+		if err := handler.OnBlobPruned(descriptorOf(blob), blob.LastAccess); err != nil {
+			return err
+		}
+	}
+	if !opts.dryRun {
+		if err := cache.RemoveStaleLocks(ctx, opts.Root); err != nil {
+			return err
+		}
+	}
+	return handler.Render()
+}
@@ -18,6 +18,7 @@ package root
 import (
 	"github.com/spf13/cobra"
 	"oras.land/oras/cmd/oras/root/blob"
+	"oras.land/oras/cmd/oras/root/cache"
 	"oras.land/oras/cmd/oras/root/manifest"
 	"oras.land/oras/cmd/oras/root/repo"
 )
@@ -34,13 +35,17 @@ func New() *cobra.Command {
 		logoutCmd(),
 		versionCmd(),
 		discoverCmd(),
+		inspectCmd(),
 		resolveCmd(),
 		copyCmd(),
 		tagCmd(),
 		attachCmd(),
 		backupCmd(),
 		restoreCmd(),
+		signCmd(),
+		verifyCmd(),
 		blob.Cmd(),
+		cache.Cmd(),
 		manifest.Cmd(),
 		repo.Cmd(),
 	)
@@ -43,6 +43,7 @@ import (
 )
 
 type copyOptions struct {
+	option.Cache
 	option.Common
 	option.Platform
 	option.BinaryTarget
@@ -85,6 +86,9 @@ Example - Copy an artifact and referrers using specific methods for the Referrer
 Example - Copy certain platform of an artifact:
   oras cp --platform linux/arm/v5 localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
 
+Example - Copy an artifact reading the blobs through the local cache:
+  oras cp --cache ~/.oras/cache localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
 Example - Copy an artifact with multiple tags:
   oras cp localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:tag1,tag2,tag3
 
@@ -179,6 +183,9 @@ func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOn
 			return []string{srcRepo.Reference.Repository}, nil
 		}
 	}
+	if src, err = opts.CachedGraphTarget(src); err != nil {
+		return desc, err
+	}
 	dst, err = copyHandler.StartTracking(dst)
 	if err != nil {
 		return desc, err
@@ -16,14 +16,24 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/registry"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
@@ -31,17 +41,24 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/graph"
 )
 
 type discoverOptions struct {
+	option.Cache
 	option.Common
 	option.Platform
 	option.Target
 	option.Format
 	option.Terminal
 
-	artifactType string
-	depth        int
+	artifactType     string
+	depth            int
+	includeContent   bool
+	includeManifests bool
+	ancestors        bool
+	filter           referrerFilter
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -70,12 +87,30 @@ Example - [Experimental] Discover referrers and display in a table view:
 Example - [Experimental] Discover referrers and format output with Go template:
   oras discover localhost:5000/hello:v1 --format go-template --template "{{.referrers}}"
 
+Example - Discover referrers of the multi-arch image 'hello:v1' and of each platform manifest in it:
+  oras discover --include-manifests localhost:5000/hello:v1
+
+Example - Discover the subject chain of the signature 'sha256:9463...' up to the root artifact, with the tags of each artifact:
+  oras discover --ancestors localhost:5000/hello@sha256:9463e0d192846bc994279417b50114606712d516aab45f4d8b31cbc6e46aad71
+
+Example - [Experimental] Discover referrers and export the artifact graph in Graphviz DOT language:
+  oras discover localhost:5000/hello:v1 --format dot | dot -Tsvg -o graph.svg
+
+Example - [Experimental] Discover referrers and export the artifact graph with configs and layers as a Mermaid flowchart:
+  oras discover localhost:5000/hello:v1 --format mermaid --include-content
+
 Example - [Experimental] Discover only direct referrers, displayed in json view:
   oras discover localhost:5000/hello:v1 --format json --depth 1
 
 Example - Discover referrers with type 'test-artifact' of manifest 'hello:v1' in registry 'localhost:5000':
   oras discover --artifact-type test-artifact localhost:5000/hello:v1
 
+Example - Discover referrers annotated with 'env=prod' and created in 2024, sorted by creation time:
+  oras discover --annotation env=prod --created-after 2024-01-01 --created-before 2025-01-01 --sort created localhost:5000/hello:v1
+
+Example - Print the digest of the latest direct referrer with type 'test-artifact':
+  oras discover --artifact-type test-artifact --latest --depth 1 --format go-template --template '{{range .referrers}}{{.digest}}{{end}}' localhost:5000/hello:v1
+
 Example - Discover referrers of the manifest tagged 'v1' in an OCI image layout folder 'layout-dir':
   oras discover --oci-layout layout-dir:v1
 
@@ -98,6 +133,36 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 			if err := option.Parse(cmd, &opts); err != nil {
 				return err
 			}
+			if opts.ancestors {
+				for _, flag := range []string{"depth", "artifact-type", "include-manifests", "include-content", "annotation", "annotation-exists", "created-after", "created-before", "sort", "latest"} {
+					if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "ancestors", flag); err != nil {
+						return err
+					}
+				}
+				switch opts.Format.Type {
+				case option.FormatTypeTree.Name, option.FormatTypeJSON.Name, option.FormatTypeGoTemplate.Name:
+				default:
+					return &oerrors.Error{
+						Err:            fmt.Errorf("`--ancestors` cannot be used with `--format %s`", opts.Format.Type),
+						Recommendation: "use `--format tree`, `--format json` or `--format go-template` instead",
+					}
+				}
+			}
+			if err := opts.filter.parse(); err != nil {
+				return err
+			}
+			if opts.includeContent && opts.Format.Type != option.FormatTypeDOT.Name && opts.Format.Type != option.FormatTypeMermaid.Name {
+				return &oerrors.Error{
+					Err:            errors.New("`--include-content` can only be used with `--format dot` or `--format mermaid`"),
+					Recommendation: "add `--format dot` or `--format mermaid` to render the artifact graph",
+				}
+			}
+			if opts.includeManifests && opts.Format.Type == option.FormatTypeTable.Name {
+				return &oerrors.Error{
+					Err:            errors.New("`--include-manifests` cannot be used with `--format table`"),
+					Recommendation: "use `--format tree` or `--format json` instead",
+				}
+			}
 			if cmd.Flags().Changed("output") {
 				switch opts.Format.Type {
 				case option.FormatTypeTree.Name, option.FormatTypeJSON.Name, option.FormatTypeTable.Name:
@@ -118,12 +183,23 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 	cmd.Flags().StringVarP(&opts.FormatFlag, "output", "o", "tree", "[Deprecated] format in which to display referrers (table, json, or tree).")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "display full metadata of referrers")
 	cmd.Flags().IntVarP(&opts.depth, "depth", "", 0, "[Experimental] level of referrers to display, if unused shows referrers of all levels")
+	cmd.Flags().BoolVarP(&opts.ancestors, "ancestors", "", false, "discover the subject chain of the artifact up to the root instead of its referrers")
+	cmd.Flags().BoolVarP(&opts.includeManifests, "include-manifests", "", false, "discover the referrers of the child manifests if the artifact is an index, grouped by platform")
+	cmd.Flags().StringArrayVarP(&opts.filter.annotations, "annotation", "", nil, "only discover referrers with the annotation in the form of key=value, can be specified multiple times")
+	cmd.Flags().StringArrayVarP(&opts.filter.annotationKeys, "annotation-exists", "", nil, "only discover referrers with the annotation key, can be specified multiple times")
+	cmd.Flags().StringVarP(&opts.filter.createdAfterFlag, "created-after", "", "", "only discover referrers created after the time in RFC 3339 format or as a date (YYYY-MM-DD), according to the annotation \""+ocispec.AnnotationCreated+"\"")
+	cmd.Flags().StringVarP(&opts.filter.createdBeforeFlag, "created-before", "", "", "only discover referrers created before the time in RFC 3339 format or as a date (YYYY-MM-DD), according to the annotation \""+ocispec.AnnotationCreated+"\"")
+	cmd.Flags().StringVarP(&opts.filter.sortBy, "sort", "", "", "sort the referrers of each artifact, options: created")
+	cmd.Flags().BoolVarP(&opts.filter.latest, "latest", "", false, "only discover the referrer created the latest among the matched referrers of each artifact")
+	cmd.Flags().BoolVarP(&opts.includeContent, "include-content", "", false, "[Experimental] include the child manifests, configs and layers of the discovered manifests in the artifact graph, used with --format dot or mermaid")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.SetTypes(
 		option.FormatTypeTree,
 		option.FormatTypeTable,
 		option.FormatTypeJSON.WithUsage("Get referrers and output in JSON format"),
 		option.FormatTypeGoTemplate.WithUsage("Print referrers using the given Go template"),
+		option.FormatTypeDOT.WithUsage("Export the artifact graph in Graphviz DOT language"),
+		option.FormatTypeMermaid.WithUsage("Export the artifact graph as a Mermaid flowchart"),
 	)
 	opts.EnableDistributionSpecFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
@@ -133,13 +209,17 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 
 func runDiscover(cmd *cobra.Command, opts *discoverOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
-	repo, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
 	}
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
+	repo, err := opts.CachedGraphTarget(target)
+	if err != nil {
+		return err
+	}
 
 	// discover artifacts
 	resolveOpts := oras.DefaultResolveOptions
@@ -148,22 +228,32 @@ func runDiscover(cmd *cobra.Command, opts *discoverOptions) error {
 	if err != nil {
 		return err
 	}
+	if opts.ancestors {
+		return discoverAncestors(ctx, target, repo, desc, logger, opts)
+	}
 
 	handler, err := display.NewDiscoverHandler(opts.Printer, opts.Format, opts.Path, opts.RawReference, desc, opts.verbose, opts.TTY)
 	if err != nil {
 		return err
 	}
-	if err := fetchAllReferrers(ctx, repo, desc, opts.artifactType, handler, opts.depth); err != nil {
+	if err := fetchContents(ctx, repo, desc, handler, opts); err != nil {
+		return err
+	}
+	if err := fetchAllReferrers(ctx, repo, desc, handler, opts.depth, opts); err != nil {
+		return err
+	}
+	if err := fetchManifests(ctx, repo, desc, handler, opts); err != nil {
 		return err
 	}
 	return handler.Render()
 }
 
-func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, artifactType string, handler metadata.DiscoverHandler, depth int) error {
-	results, err := registry.Referrers(ctx, repo, desc, artifactType)
+func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, handler metadata.DiscoverHandler, depth int, opts *discoverOptions) error {
+	results, err := registry.Referrers(ctx, repo, desc, opts.artifactType)
 	if err != nil {
 		return err
 	}
+	results = opts.filter.apply(results)
 
 	var nextDepth int
 	if depth > 0 {
@@ -173,6 +263,9 @@ func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc
 		if err := handler.OnDiscovered(r, desc); err != nil {
 			return err
 		}
+		if err := fetchContents(ctx, repo, r, handler, opts); err != nil {
+			return err
+		}
 		if depth == 1 {
 			continue
 		}
@@ -180,9 +273,296 @@ func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc
 			Digest:    r.Digest,
 			Size:      r.Size,
 			MediaType: r.MediaType,
-		}, artifactType, handler, nextDepth); err != nil {
+		}, handler, nextDepth, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discoverAncestors discovers the subject chain of the artifact up to the root,
+// along with the artifact types and the tags of the artifacts if the target
+// supports tag listing.
+func discoverAncestors(ctx context.Context, target oras.ReadOnlyTarget, repo oras.ReadOnlyTarget, desc ocispec.Descriptor, logger logrus.FieldLogger, opts *discoverOptions) error {
+	handler, err := display.NewDiscoverAncestorsHandler(opts.Printer, opts.Format, opts.Path, opts.TTY)
+	if err != nil {
+		return err
+	}
+	tags, err := findTags(ctx, target, logger)
+	if err != nil {
+		logger.Warnf("failed to list tags: %v", err)
+	}
+	visited := make(map[digest.Digest]bool)
+	for node := &desc; node != nil; {
+		if visited[node.Digest] {
+			return fmt.Errorf("subject cycle detected at %s", node.Digest)
+		}
+		visited[node.Digest] = true
+		fetched, err := content.FetchAll(ctx, repo, *node)
+		if err != nil {
+			return err
+		}
+		_, subject, config, err := graph.Successors(ctx, bytesFetcher(fetched), *node)
+		if err != nil {
+			return err
+		}
+		ancestor := *node
+		if ancestor.ArtifactType, err = artifactTypeOf(fetched, config); err != nil {
+			return err
+		}
+		if err := handler.OnAncestorDiscovered(ancestor, tags[ancestor.Digest]); err != nil {
+			return err
+		}
+		node = subject
+	}
+	return handler.Render()
+}
+
+// bytesFetcher returns a fetcher of the fetched content.
+func bytesFetcher(fetched []byte) content.Fetcher {
+	return content.FetcherFunc(func(context.Context, ocispec.Descriptor) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(fetched)), nil
+	})
+}
+
+// artifactTypeOf returns the artifact type of the manifest, which is the
+// config media type of an image manifest without artifact type.
+func artifactTypeOf(manifest []byte, config *ocispec.Descriptor) (string, error) {
+	var fields struct {
+		ArtifactType string `json:"artifactType"`
+	}
+	if err := json.Unmarshal(manifest, &fields); err != nil {
+		return "", err
+	}
+	if fields.ArtifactType == "" && config != nil {
+		return config.MediaType, nil
+	}
+	return fields.ArtifactType, nil
+}
+
+const (
+	// maxFoundTags is the maximum number of tags looked up to find the tags
+	// of the artifacts.
+	maxFoundTags = 1000
+	// findTagsConcurrency is the number of tags resolved concurrently.
+	findTagsConcurrency = 10
+)
+
+// errTooManyTags stops listing tags once maxFoundTags tags are listed.
+var errTooManyTags = errors.New("too many tags")
+
+// findTags finds the tags resolving to each digest if the target supports tag
+// listing. At most maxFoundTags tags are looked up, and the tags failing to
+// resolve are skipped.
+func findTags(ctx context.Context, target oras.ReadOnlyTarget, logger logrus.FieldLogger) (map[digest.Digest][]string, error) {
+	lister, ok := target.(registry.TagLister)
+	if !ok {
+		return nil, nil
+	}
+	var tags []string
+	err := lister.Tags(ctx, "", func(page []string) error {
+		tags = append(tags, page...)
+		if len(tags) > maxFoundTags {
+			return errTooManyTags
+		}
+		return nil
+	})
+	if errors.Is(err, errTooManyTags) {
+		logger.Warnf("only the first %d tags are looked up", maxFoundTags)
+		tags = tags[:maxFoundTags]
+	} else if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]digest.Digest, len(tags))
+	var eg errgroup.Group
+	eg.SetLimit(findTagsConcurrency)
+	for i, tag := range tags {
+		eg.Go(func() error {
+			desc, err := target.Resolve(ctx, tag)
+			if err != nil {
+				logger.Debugf("skipped tag %s: %v", tag, err)
+				return nil
+			}
+			resolved[i] = desc.Digest
+			return nil
+		})
+	}
+	_ = eg.Wait()
+	found := make(map[digest.Digest][]string)
+	for i, tag := range tags {
+		if resolved[i] != "" {
+			found[resolved[i]] = append(found[resolved[i]], tag)
+		}
+	}
+	return found, nil
+}
+
+// fetchManifests reports the child manifests of the index to the handler if
+// manifests are included, and discovers their referrers, recursing into the
+// child indexes.
+func fetchManifests(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, handler metadata.DiscoverHandler, opts *discoverOptions) error {
+	manifestHandler, ok := handler.(metadata.DiscoverManifestHandler)
+	if !ok || !opts.includeManifests || !descriptor.IsIndex(desc) {
+		return nil
+	}
+	manifests, _, _, err := graph.Successors(ctx, repo, desc)
+	if err != nil {
+		return err
+	}
+	for _, manifest := range manifests {
+		if err := manifestHandler.OnManifestDiscovered(manifest, desc); err != nil {
+			return err
+		}
+		if err := fetchAllReferrers(ctx, repo, manifest, handler, opts.depth, opts); err != nil {
+			return err
+		}
+		if err := fetchManifests(ctx, repo, manifest, handler, opts); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// fetchContents reports the child manifests, config and layers of the manifest
+// to the graph handler if contents are included, recursing into the child
+// manifests.
+func fetchContents(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor, handler metadata.DiscoverHandler, opts *discoverOptions) error {
+	graphHandler, ok := handler.(metadata.DiscoverGraphHandler)
+	if !ok || !opts.includeContent {
+		return nil
+	}
+	nodes, _, config, err := graph.Successors(ctx, fetcher, desc)
+	if err != nil {
+		return err
+	}
+	if config != nil {
+		nodes = append([]ocispec.Descriptor{*config}, nodes...)
+	}
+	for _, node := range nodes {
+		if err := graphHandler.OnContentDiscovered(node, desc); err != nil {
+			return err
+		}
+		if descriptor.IsManifest(node) {
+			if err := fetchContents(ctx, fetcher, node, handler, opts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sortByCreated is the sort key to sort the referrers by creation time.
+const sortByCreated = "created"
+
+// referrerFilter filters and sorts the discovered referrers of each artifact.
+type referrerFilter struct {
+	annotations       []string
+	annotationKeys    []string
+	createdAfterFlag  string
+	createdBeforeFlag string
+	sortBy            string
+	latest            bool
+
+	annotationValues map[string]string
+	createdAfter     time.Time
+	createdBefore    time.Time
+}
+
+// parse parses the filter flags.
+func (f *referrerFilter) parse() error {
+	f.annotationValues = make(map[string]string)
+	for _, annotation := range f.annotations {
+		key, value, ok := strings.Cut(annotation, "=")
+		if !ok || key == "" {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("invalid annotation filter %q", annotation),
+				Recommendation: `Please use the correct format in the flag: --annotation "key=value"`,
+			}
+		}
+		if existing, ok := f.annotationValues[key]; ok && existing != value {
+			return fmt.Errorf("conflicting values of annotation filter %q: %q and %q", key, existing, value)
+		}
+		f.annotationValues[key] = value
+	}
+	var err error
+	if f.createdAfter, err = parseFilterTime("created-after", f.createdAfterFlag); err != nil {
+		return err
+	}
+	if f.createdBefore, err = parseFilterTime("created-before", f.createdBeforeFlag); err != nil {
+		return err
+	}
+	if !f.createdAfter.IsZero() && !f.createdBefore.IsZero() && !f.createdAfter.Before(f.createdBefore) {
+		return errors.New("the time of `--created-after` should be earlier than the time of `--created-before`")
+	}
+	if f.sortBy != "" && f.sortBy != sortByCreated {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid sort key %q", f.sortBy),
+			Recommendation: "use `--sort created` to sort the referrers by creation time",
+		}
+	}
+	return nil
+}
+
+// parseFilterTime parses the time of the flag in RFC 3339 format or as a date.
+func parseFilterTime(flag, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, &oerrors.Error{
+		Err:            fmt.Errorf("invalid time %q for `--%s`", value, flag),
+		Recommendation: "use a time in RFC 3339 format like 2024-01-02T15:04:05Z, or a date like 2024-01-02",
+	}
+}
+
+// apply returns the matched referrers, sorted by creation time from the
+// oldest to the newest if required. Referrers without a valid creation time
+// never match the time filters, and are considered the oldest on sorting.
+func (f *referrerFilter) apply(referrers []ocispec.Descriptor) []ocispec.Descriptor {
+	var matched []ocispec.Descriptor
+	for _, referrer := range referrers {
+		if f.match(referrer) {
+			matched = append(matched, referrer)
+		}
+	}
+	if f.latest && len(matched) > 0 {
+		return []ocispec.Descriptor{latestReferrer(matched)}
+	}
+	if f.sortBy == sortByCreated {
+		slices.SortStableFunc(matched, func(a, b ocispec.Descriptor) int {
+			createdA, _ := descriptor.CreatedTime(a)
+			createdB, _ := descriptor.CreatedTime(b)
+			return createdA.Compare(createdB)
+		})
+	}
+	return matched
+}
+
+// match returns true if the referrer matches all the filters.
+func (f *referrerFilter) match(referrer ocispec.Descriptor) bool {
+	for key, value := range f.annotationValues {
+		if actual, ok := referrer.Annotations[key]; !ok || actual != value {
+			return false
+		}
+	}
+	for _, key := range f.annotationKeys {
+		if _, ok := referrer.Annotations[key]; !ok {
+			return false
+		}
+	}
+	if f.createdAfter.IsZero() && f.createdBefore.IsZero() {
+		return true
+	}
+	created, ok := descriptor.CreatedTime(referrer)
+	if !ok {
+		return false
+	}
+	return (f.createdAfter.IsZero() || created.After(f.createdAfter)) &&
+		(f.createdBefore.IsZero() || created.Before(f.createdBefore))
+}
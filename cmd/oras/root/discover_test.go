@@ -0,0 +1,245 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func pushManifest(t *testing.T, store *oci.Store, manifest ocispec.Manifest) ocispec.Descriptor {
+	t.Helper()
+	manifest.Versioned.SchemaVersion = 2
+	manifest.MediaType = ocispec.MediaTypeImageManifest
+	manifest.Config = ocispec.DescriptorEmptyJSON
+	manifest.Layers = []ocispec.Descriptor{ocispec.DescriptorEmptyJSON}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := content.NewDescriptorFromBytes(manifest.MediaType, data)
+	if err := store.Push(context.Background(), desc, bytes.NewReader(data)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+	return desc
+}
+
+func Test_discoverAncestors(t *testing.T) {
+	ctx := context.Background()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal("oci.New() error =", err)
+	}
+	if err := store.Push(ctx, ocispec.DescriptorEmptyJSON, bytes.NewReader(ocispec.DescriptorEmptyJSON.Data)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+	root := pushManifest(t, store, ocispec.Manifest{ArtifactType: "test/root"})
+	sbom := pushManifest(t, store, ocispec.Manifest{ArtifactType: "test/sbom", Subject: &root})
+	signature := pushManifest(t, store, ocispec.Manifest{Subject: &sbom})
+	for _, tag := range []string{"v1", "latest"} {
+		if err := store.Tag(ctx, root, tag); err != nil {
+			t.Fatal("Tag() error =", err)
+		}
+	}
+
+	var out bytes.Buffer
+	opts := &discoverOptions{
+		Common: option.Common{Printer: output.NewPrinter(&out, &out)},
+		Format: option.Format{Type: option.FormatTypeJSON.Name},
+		Target: option.Target{Path: "test"},
+	}
+	if err := discoverAncestors(ctx, store, store, signature, logrus.New(), opts); err != nil {
+		t.Fatal("discoverAncestors() error =", err)
+	}
+	var got []struct {
+		Digest       digest.Digest
+		ArtifactType string
+		Tags         []string
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		Digest       digest.Digest
+		ArtifactType string
+		Tags         []string
+	}{
+		{signature.Digest, ocispec.MediaTypeEmptyJSON, []string{}},
+		{sbom.Digest, "test/sbom", []string{}},
+		{root.Digest, "test/root", []string{"latest", "v1"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("discoverAncestors() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_findTags_notLister(t *testing.T) {
+	var target oras.ReadOnlyTarget = struct{ oras.ReadOnlyTarget }{}
+	tags, err := findTags(context.Background(), target, logrus.New())
+	if err != nil || tags != nil {
+		t.Errorf("findTags() = %v, %v, want nil", tags, err)
+	}
+}
+
+// tagListingTarget lists the tags in pages and resolves the tags prefixed by
+// "v1" to the same digest.
+type tagListingTarget struct {
+	oras.ReadOnlyTarget
+	pages [][]string
+}
+
+func (t *tagListingTarget) Tags(_ context.Context, _ string, fn func(tags []string) error) error {
+	for _, page := range t.pages {
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *tagListingTarget) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	if reference == "broken" {
+		return ocispec.Descriptor{}, errdef.ErrNotFound
+	}
+	if strings.HasPrefix(reference, "v1") {
+		reference = "v1"
+	}
+	return ocispec.Descriptor{Digest: digest.FromString(reference)}, nil
+}
+
+func Test_findTags(t *testing.T) {
+	target := &tagListingTarget{pages: [][]string{{"v1", "broken"}, {"v1.0", "v2"}}}
+	got, err := findTags(context.Background(), target, logrus.New())
+	if err != nil {
+		t.Fatal("findTags() error =", err)
+	}
+	want := map[digest.Digest][]string{
+		digest.FromString("v1"): {"v1", "v1.0"},
+		digest.FromString("v2"): {"v2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findTags() = %v, want %v", got, want)
+	}
+}
+
+func Test_findTags_limit(t *testing.T) {
+	var pages [][]string
+	for i := range 3 {
+		var page []string
+		for j := range maxFoundTags / 2 {
+			page = append(page, fmt.Sprintf("tag-%d-%d", i, j))
+		}
+		pages = append(pages, page)
+	}
+	got, err := findTags(context.Background(), &tagListingTarget{pages: pages}, logrus.New())
+	if err != nil {
+		t.Fatal("findTags() error =", err)
+	}
+	if len(got) != maxFoundTags {
+		t.Errorf("findTags() found %d tags, want %d", len(got), maxFoundTags)
+	}
+	if _, ok := got[digest.FromString("tag-2-0")]; ok {
+		t.Error("findTags() looked up tags beyond the limit")
+	}
+}
+
+func Test_referrerFilter_parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  referrerFilter
+		wantErr bool
+	}{
+		{"no filter", referrerFilter{}, false},
+		{"annotations", referrerFilter{annotations: []string{"a=b", "c=", "a=b"}}, false},
+		{"invalid annotation", referrerFilter{annotations: []string{"a"}}, true},
+		{"conflicting annotations", referrerFilter{annotations: []string{"a=b", "a=c"}}, true},
+		{"dates", referrerFilter{createdAfterFlag: "2023-01-01", createdBeforeFlag: "2023-01-18T08:37:50+08:00"}, false},
+		{"invalid time", referrerFilter{createdAfterFlag: "yesterday"}, true},
+		{"empty time range", referrerFilter{createdAfterFlag: "2023-01-02", createdBeforeFlag: "2023-01-01"}, true},
+		{"sort", referrerFilter{sortBy: "created"}, false},
+		{"invalid sort key", referrerFilter{sortBy: "size"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.parse(); (err != nil) != tt.wantErr {
+				t.Errorf("referrerFilter.parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_referrerFilter_apply(t *testing.T) {
+	newReferrer := func(created string, annotations ...string) ocispec.Descriptor {
+		desc := ocispec.Descriptor{
+			MediaType:   ocispec.MediaTypeImageManifest,
+			Digest:      digest.FromString(created + strings.Join(annotations, ",")),
+			Annotations: map[string]string{},
+		}
+		if created != "" {
+			desc.Annotations[ocispec.AnnotationCreated] = created
+		}
+		for _, annotation := range annotations {
+			key, value, _ := strings.Cut(annotation, "=")
+			desc.Annotations[key] = value
+		}
+		return desc
+	}
+	newest := newReferrer("2023-01-18T08:37:57Z", "env=prod", "signed=")
+	oldest := newReferrer("2023-01-16T05:49:46Z", "env=dev")
+	middle := newReferrer("2023-01-18T08:37:42Z", "env=prod")
+	undated := newReferrer("", "env=prod", "signed=")
+	referrers := []ocispec.Descriptor{newest, oldest, middle, undated}
+
+	tests := []struct {
+		name   string
+		filter referrerFilter
+		want   []ocispec.Descriptor
+	}{
+		{"no filter", referrerFilter{}, referrers},
+		{"annotation", referrerFilter{annotations: []string{"env=prod"}}, []ocispec.Descriptor{newest, middle, undated}},
+		{"annotation exists", referrerFilter{annotationKeys: []string{"signed"}}, []ocispec.Descriptor{newest, undated}},
+		{"created after", referrerFilter{createdAfterFlag: "2023-01-17"}, []ocispec.Descriptor{newest, middle}},
+		{"created before", referrerFilter{createdBeforeFlag: "2023-01-18T08:37:50Z"}, []ocispec.Descriptor{oldest, middle}},
+		{"sort", referrerFilter{sortBy: "created"}, []ocispec.Descriptor{undated, oldest, middle, newest}},
+		{"latest", referrerFilter{latest: true, annotations: []string{"env=prod"}}, []ocispec.Descriptor{newest}},
+		{"latest without match", referrerFilter{latest: true, annotations: []string{"env=test"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.parse(); err != nil {
+				t.Fatal("referrerFilter.parse() error =", err)
+			}
+			if got := tt.filter.apply(referrers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("referrerFilter.apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,49 +16,154 @@ limitations under the License.
 package root
 
 import (
+	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"io/fs"
+	"maps"
+	"os"
 	"path/filepath"
 
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2/content/file"
 	"oras.land/oras/cmd/oras/internal/display/status"
 	"oras.land/oras/cmd/oras/internal/fileref"
+	"oras.land/oras/internal/chunk"
+	orasfile "oras.land/oras/internal/file"
+	orasio "oras.land/oras/internal/io"
+	"oras.land/oras/internal/mediatype"
+	"oras.land/oras/internal/pathfilter"
 )
 
-func loadFiles(ctx context.Context, store *file.Store, annotations map[string]map[string]string, fileRefs []string, displayStatus status.PushHandler) ([]ocispec.Descriptor, error) {
-	var files []ocispec.Descriptor
+// stdinFileName is the file path representing content read from stdin.
+const stdinFileName = "-"
+
+// loadOptions contains optional settings for loading files to be pushed.
+type loadOptions struct {
+	// stdinName is the file name of the content read from stdin.
+	stdinName string
+	// spool stores the content read from stdin.
+	spool *orasfile.Spool
+	// chunkSize is the size of each chunk when splitting large files. Files
+	// are not split if chunkSize is not positive.
+	chunkSize int64
+	// chunks stores the chunks of large files.
+	chunks *chunk.Store
+	// detector, if not nil, detects the media types of files specified
+	// without a media type.
+	detector *mediatype.Detector
+	// filter, if not empty, filters the contents of directories.
+	filter *pathfilter.Filter
+	// preservePermissions records the permission bits and the modification
+	// times of the files as annotations.
+	preservePermissions bool
+	// tempFiles are the temporary files to be removed on close.
+	tempFiles []string
+}
+
+// close releases the resources held for loading files.
+func (opts *loadOptions) close() error {
+	var errs []error
+	if opts.spool != nil {
+		errs = append(errs, opts.spool.Close())
+	}
+	for _, name := range opts.tempFiles {
+		errs = append(errs, os.Remove(name))
+	}
+	opts.tempFiles = nil
+	return errors.Join(errs...)
+}
+
+// fileSpec describes a file to be pushed.
+type fileSpec struct {
+	// path is the path of the file to be read.
+	path string
+	// name is the name of the file in the artifact. The cleaned path is used
+	// if name is empty.
+	name string
+	// mediaType is the media type of the file. It is detected or defaulted if
+	// empty.
+	mediaType string
+}
+
+// parseFileRefs parses the file references in the format <file>[:<type>].
+func parseFileRefs(fileRefs []string) ([]fileSpec, error) {
+	files := make([]fileSpec, 0, len(fileRefs))
 	for _, fileRef := range fileRefs {
-		filename, mediaType, err := fileref.Parse(fileRef, "")
+		path, mediaType, err := fileref.Parse(fileRef, "")
 		if err != nil {
 			return nil, err
 		}
+		files = append(files, fileSpec{path: path, mediaType: mediaType})
+	}
+	return files, nil
+}
+
+func loadFiles(ctx context.Context, store *file.Store, opts *loadOptions, annotations map[string]map[string]string, fileSpecs []fileSpec, displayStatus status.PushHandler) ([]ocispec.Descriptor, error) {
+	if opts == nil {
+		opts = &loadOptions{}
+	}
+	var files []ocispec.Descriptor
+	for _, f := range fileSpecs {
+		filename, mediaType := f.path, f.mediaType
+		if filename == stdinFileName && opts.spool != nil {
+			file, err := loadStdin(opts, annotations, mediaType, displayStatus)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+			continue
+		}
 
 		// get shortest absolute path as unique name
-		name := filepath.Clean(filename)
+		name := f.name
+		if name == "" {
+			name = filepath.Clean(filename)
+		}
 		if !filepath.IsAbs(name) {
 			name = filepath.ToSlash(name)
 		}
 
-		err = displayStatus.OnFileLoading(name)
+		err := displayStatus.OnFileLoading(name)
 		if err != nil {
 			return nil, err
 		}
-		file, err := addFile(ctx, store, name, mediaType, filename)
+		if mediaType == "" {
+			if mediaType, err = opts.detectMediaType(filename, name, displayStatus); err != nil {
+				return nil, err
+			}
+		}
+		fileAnnotations := opts.fileAnnotations(filename, annotations[filename])
+		if opts.shouldChunk(filename) {
+			if mediaType == "" {
+				mediaType = ocispec.MediaTypeImageLayer
+			}
+			chunks, err := opts.chunks.Add(filename, name, mediaType, opts.chunkSize)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range chunks {
+				files = append(files, applyFileAnnotations(c, maps.Clone(fileAnnotations)))
+			}
+			continue
+		}
+		excluded, err := opts.directoryFilter(filename)
 		if err != nil {
 			return nil, err
 		}
-		if value, ok := annotations[filename]; ok {
-			if file.Annotations == nil {
-				file.Annotations = value
-			} else {
-				for k, v := range value {
-					file.Annotations[k] = v
-				}
-			}
+		var file ocispec.Descriptor
+		if excluded != nil {
+			file, err = opts.addDirectory(ctx, store, name, mediaType, filename, excluded, displayStatus)
+		} else {
+			file, err = addFile(ctx, store, name, mediaType, filename)
+		}
+		if err != nil {
+			return nil, err
 		}
-		files = append(files, file)
+		files = append(files, applyFileAnnotations(file, fileAnnotations))
 	}
 	if len(files) == 0 {
 		if err := displayStatus.OnEmptyArtifact(); err != nil {
@@ -68,6 +173,151 @@ func loadFiles(ctx context.Context, store *file.Store, annotations map[string]ma
 	return files, nil
 }
 
+// fileAnnotations returns the annotations of the file at filename, including
+// the ones recording its permission bits and modification time if
+// preservePermissions is set. The given annotations take precedence.
+func (opts *loadOptions) fileAnnotations(filename string, annotations map[string]string) map[string]string {
+	if !opts.preservePermissions {
+		return annotations
+	}
+	fi, err := os.Stat(filename)
+	if err != nil {
+		// leave the error to be reported when adding the file
+		return annotations
+	}
+	ret := orasfile.ModeAnnotations(fi)
+	maps.Copy(ret, annotations)
+	return ret
+}
+
+// shouldChunk returns true if the file is a regular file larger than the
+// chunk size.
+func (opts *loadOptions) shouldChunk(filename string) bool {
+	if opts.chunks == nil || opts.chunkSize <= 0 {
+		return false
+	}
+	fi, err := os.Stat(filename)
+	return err == nil && fi.Mode().IsRegular() && fi.Size() > opts.chunkSize
+}
+
+// detectMediaType detects the media type of the regular file at filename. It
+// returns an empty media type for directories or if detection is disabled.
+func (opts *loadOptions) detectMediaType(filename string, name string, displayStatus status.PushHandler) (string, error) {
+	if opts.detector == nil {
+		return "", nil
+	}
+	fi, err := os.Stat(filename)
+	if err != nil || !fi.Mode().IsRegular() {
+		// leave the error to be reported when adding the file
+		return "", nil
+	}
+	mediaType, err := opts.detector.Detect(filename, name)
+	if err != nil {
+		return "", err
+	}
+	if mediaType == "" {
+		mediaType = ocispec.MediaTypeImageLayer
+	}
+	return mediaType, displayStatus.OnMediaTypeDetected(name, mediaType)
+}
+
+// directoryFilter returns the function deciding whether a path relative to
+// the directory dir is excluded by the filter or by the patterns in the ignore
+// file of the directory. It returns nil if dir is not a directory or nothing
+// in it is excluded, so that the directory is packed by the file store as is.
+func (opts *loadOptions) directoryFilter(dir string) (func(name string, isDir bool) bool, error) {
+	fi, err := os.Stat(dir)
+	if err != nil || !fi.IsDir() {
+		// leave the error to be reported when adding the file
+		return nil, nil
+	}
+	ignoreFile := filepath.Join(dir, pathfilter.IgnoreFileName)
+	patterns, err := pathfilter.LoadIgnoreFile(ignoreFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(patterns) == 0 && opts.filter.IsEmpty() {
+		return nil, nil
+	}
+	ignore, err := pathfilter.New(nil, patterns)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ignore file %s: %w", ignoreFile, err)
+	}
+	excluded := func(name string, isDir bool) bool {
+		return ignore.Excluded(name, isDir) || opts.filter.Excluded(filepath.Join(dir, name), isDir)
+	}
+
+	var found bool
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if excluded(name, d.IsDir()) {
+			found = true
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil || !found {
+		return nil, err
+	}
+	return excluded, nil
+}
+
+// addDirectory packs the directory into a tar+gzip layer in the same format
+// as the file store does, leaving out the contents excluded.
+func (opts *loadOptions) addDirectory(ctx context.Context, store *file.Store, name string, mediaType string, dir string, excluded func(name string, isDir bool) bool, displayStatus status.PushHandler) (ocispec.Descriptor, error) {
+	fp, err := os.CreateTemp("", "oras_dir_*.tar.gz")
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	opts.tempFiles = append(opts.tempFiles, fp.Name())
+	defer func() { _ = fp.Close() }()
+
+	var reportErr error
+	skip := func(path string, isDir bool) bool {
+		if !excluded(path, isDir) {
+			return false
+		}
+		if err := displayStatus.OnFileExcluded(filepath.ToSlash(filepath.Join(dir, path))); err != nil && reportErr == nil {
+			reportErr = err
+		}
+		return true
+	}
+	tarDigester := digest.Canonical.Digester()
+	gzw := gzip.NewWriter(fp)
+	if err := orasio.TarDirectoryFunc(io.MultiWriter(gzw, tarDigester.Hash()), dir, name, skip); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to tar %s: %w", dir, err)
+	}
+	if reportErr != nil {
+		return ocispec.Descriptor{}, reportErr
+	}
+	if err := gzw.Close(); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if err := fp.Close(); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	if mediaType == "" {
+		mediaType = ocispec.MediaTypeImageLayerGzip
+	}
+	desc, err := addFile(ctx, store, name, mediaType, fp.Name())
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc.Annotations[file.AnnotationDigest] = tarDigester.Digest().String()
+	desc.Annotations[file.AnnotationUnpack] = "true"
+	return desc, nil
+}
+
 func addFile(ctx context.Context, store *file.Store, name string, mediaType string, filename string) (ocispec.Descriptor, error) {
 	file, err := store.Add(ctx, name, mediaType, filename)
 	if err != nil {
@@ -79,3 +329,40 @@ func addFile(ctx context.Context, store *file.Store, name string, mediaType stri
 	}
 	return file, nil
 }
+
+func loadStdin(opts *loadOptions, annotations map[string]map[string]string, mediaType string, displayStatus status.PushHandler) (ocispec.Descriptor, error) {
+	if err := displayStatus.OnFileLoading(opts.stdinName); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if mediaType == "" && opts.detector != nil {
+		mediaType = opts.detector.DetectByName(opts.stdinName)
+		if mediaType == "" {
+			mediaType = ocispec.MediaTypeImageLayer
+		}
+		if err := displayStatus.OnMediaTypeDetected(opts.stdinName, mediaType); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	if mediaType == "" {
+		mediaType = ocispec.MediaTypeImageLayer
+	}
+	desc, err := opts.spool.Add(os.Stdin, mediaType, opts.stdinName)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return applyFileAnnotations(desc, annotations[opts.stdinName]), nil
+}
+
+func applyFileAnnotations(desc ocispec.Descriptor, annotations map[string]string) ocispec.Descriptor {
+	if annotations == nil {
+		return desc
+	}
+	if desc.Annotations == nil {
+		desc.Annotations = annotations
+	} else {
+		for k, v := range annotations {
+			desc.Annotations[k] = v
+		}
+	}
+	return desc
+}
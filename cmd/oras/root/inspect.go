@@ -0,0 +1,271 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/docker"
+	"oras.land/oras/internal/graph"
+)
+
+// maxInspectedConfigSize is the maximum size of the image configs to be
+// summarized.
+const maxInspectedConfigSize = 4 * 1024 * 1024 // 4 MiB
+
+type inspectOptions struct {
+	option.Cache
+	option.Common
+	option.Platform
+	option.Target
+	option.Format
+	option.Terminal
+
+	showTags bool
+}
+
+func inspectCmd() *cobra.Command {
+	var opts inspectOptions
+	cmd := &cobra.Command{
+		Use:   "inspect [flags] <name>{:<tag>|@<digest>}",
+		Short: "[Preview] Inspect an artifact in a registry or an OCI image layout",
+		Long: `[Preview] Inspect an artifact in a registry or an OCI image layout
+
+The resolved descriptor, the manifest or the index with its child manifests
+grouped by platform, the config summary, the layers, the total size of the
+unique contents, and the referrers of the artifact are displayed together.
+Image configs larger than 4 MiB are not summarized. With --show-tags, the tags
+resolving to the inspected artifact are looked up among the first 1000 tags of
+the repository and displayed as well.
+
+** This command is in preview and under development. **
+
+Example - Inspect the artifact 'hello:v1' in registry 'localhost:5000', displayed in a tree view:
+  oras inspect localhost:5000/hello:v1
+
+Example - Inspect the linux/amd64 manifest of the multi-arch image 'hello:v1':
+  oras inspect --platform linux/amd64 localhost:5000/hello:v1
+
+Example - Inspect the artifact 'hello:v1' along with the tags resolving to it:
+  oras inspect --show-tags localhost:5000/hello:v1
+
+Example - Inspect the artifact 'hello:v1' and output in JSON format:
+  oras inspect --format json localhost:5000/hello:v1
+
+Example - [Experimental] Print the total size of the artifact 'hello:v1' with Go template:
+  oras inspect --format go-template --template '{{.totalSize}}' localhost:5000/hello:v1
+
+Example - Inspect the artifact tagged 'v1' in an OCI image layout folder 'layout-dir':
+  oras inspect --oci-layout layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the target artifact to inspect"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			opts.DisableTTY(opts.Debug, false)
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInspect(cmd, &opts)
+		},
+	}
+
+	opts.SetTypes(
+		option.FormatTypeTree,
+		option.FormatTypeJSON.WithUsage("Get the inspected artifact and output in JSON format"),
+		option.FormatTypeGoTemplate.WithUsage("Print the inspected artifact using the given Go template"),
+	)
+	cmd.Flags().BoolVarP(&opts.showTags, "show-tags", "", false, "look up and display the tags resolving to the inspected artifact")
+	opts.EnableDistributionSpecFlag()
+	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.Flags().Lookup(option.NoTTYFlag).Usage = "[Preview] disable colors"
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runInspect(cmd *cobra.Command, opts *inspectOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	repo, err := opts.CachedGraphTarget(target)
+	if err != nil {
+		return err
+	}
+
+	desc, err := resolveInspected(ctx, repo, opts.Reference, opts.Platform.Platform)
+	if err != nil {
+		return err
+	}
+	if !descriptor.IsManifest(desc) {
+		return fmt.Errorf("%s is not a manifest or an index: unsupported media type %q", opts.RawReference, desc.MediaType)
+	}
+	manifest, err := content.FetchAll(ctx, repo, desc)
+	if err != nil {
+		return err
+	}
+	if desc, err = withArtifactType(ctx, desc, manifest); err != nil {
+		return err
+	}
+
+	handler, err := display.NewInspectHandler(opts.Printer, opts.Format, opts.Path, desc, opts.TTY)
+	if err != nil {
+		return err
+	}
+	if opts.showTags {
+		tags, err := findTags(ctx, target, logger)
+		if err != nil {
+			logger.Warnf("failed to list tags: %v", err)
+		}
+		if err := handler.OnTagsFound(tags[desc.Digest]); err != nil {
+			return err
+		}
+	}
+	if err := inspectManifest(ctx, repo, desc, manifest, handler); err != nil {
+		return err
+	}
+	if err := inspectReferrers(ctx, repo, desc, handler); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+// resolveInspected resolves the reference to the inspected manifest, which is
+// the manifest of the platform in the resolved index if platform is not nil.
+func resolveInspected(ctx context.Context, target oras.ReadOnlyTarget, reference string, platform *ocispec.Platform) (ocispec.Descriptor, error) {
+	desc, err := oras.Resolve(ctx, target, reference, oras.DefaultResolveOptions)
+	if err != nil || platform == nil {
+		return desc, err
+	}
+	resolveOpts := oras.DefaultResolveOptions
+	resolveOpts.TargetPlatform = platform
+	return oras.Resolve(ctx, target, desc.Digest.String(), resolveOpts)
+}
+
+// withArtifactType returns the descriptor with the artifact type of the
+// fetched manifest.
+func withArtifactType(ctx context.Context, desc ocispec.Descriptor, manifest []byte) (ocispec.Descriptor, error) {
+	_, _, config, err := graph.Successors(ctx, bytesFetcher(manifest), desc)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if desc.ArtifactType, err = artifactTypeOf(manifest, config); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// inspectManifest reports the config and the layers of the fetched manifest,
+// or the child manifests of the fetched index to the handler, recursing into
+// the child manifests.
+func inspectManifest(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor, manifest []byte, handler metadata.InspectHandler) error {
+	nodes, _, config, err := graph.Successors(ctx, bytesFetcher(manifest), desc)
+	if err != nil {
+		return err
+	}
+	if config != nil {
+		image, err := fetchImageConfig(ctx, fetcher, *config)
+		if err != nil {
+			return err
+		}
+		if err := handler.OnConfigInspected(*config, image, desc); err != nil {
+			return err
+		}
+	}
+	if !descriptor.IsIndex(desc) {
+		for _, layer := range nodes {
+			if err := handler.OnLayerInspected(layer, desc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, child := range nodes {
+		childManifest, err := content.FetchAll(ctx, fetcher, child)
+		if err != nil {
+			return err
+		}
+		if child, err = withArtifactType(ctx, child, childManifest); err != nil {
+			return err
+		}
+		if err := handler.OnManifestInspected(child, desc); err != nil {
+			return err
+		}
+		if err := inspectManifest(ctx, fetcher, child, childManifest, handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchImageConfig fetches and parses the config if it is an image config,
+// returning nil for other configs and for image configs too large to be
+// summarized.
+func fetchImageConfig(ctx context.Context, fetcher content.Fetcher, config ocispec.Descriptor) (*ocispec.Image, error) {
+	if config.MediaType != ocispec.MediaTypeImageConfig && config.MediaType != docker.MediaTypeConfig {
+		return nil, nil
+	}
+	if config.Size > maxInspectedConfigSize {
+		return nil, nil
+	}
+	data, err := content.FetchAll(ctx, fetcher, config)
+	if err != nil {
+		return nil, err
+	}
+	var image ocispec.Image
+	if err := json.Unmarshal(data, &image); err != nil {
+		// ignore configs not matching the image config schema
+		return nil, nil
+	}
+	return &image, nil
+}
+
+// inspectReferrers reports the referrers of the artifact to the handler,
+// recursing into the referrers of the referrers.
+func inspectReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, handler metadata.InspectHandler) error {
+	referrers, err := registry.Referrers(ctx, repo, desc, "")
+	if err != nil {
+		return err
+	}
+	for _, referrer := range referrers {
+		if err := handler.OnReferrerDiscovered(referrer, desc); err != nil {
+			return err
+		}
+		if err := inspectReferrers(ctx, repo, referrer, handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
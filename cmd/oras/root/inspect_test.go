@@ -0,0 +1,211 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+func pushJSON(t *testing.T, store *oci.Store, mediaType string, v any) (ocispec.Descriptor, []byte) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := content.NewDescriptorFromBytes(mediaType, data)
+	if err := store.Push(context.Background(), desc, bytes.NewReader(data)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+	return desc, data
+}
+
+func Test_inspect(t *testing.T) {
+	ctx := context.Background()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal("oci.New() error =", err)
+	}
+	layerData := []byte("foo")
+	layer := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, layerData)
+	if err := store.Push(ctx, layer, bytes.NewReader(layerData)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+	config, _ := pushJSON(t, store, ocispec.MediaTypeImageConfig, ocispec.Image{
+		Author:   "test",
+		Platform: ocispec.Platform{OS: "linux", Architecture: "arm64"},
+	})
+	// the child manifests share the same layer
+	amd64, _ := pushJSON(t, store, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    ocispec.DescriptorEmptyJSON,
+		Layers:    []ocispec.Descriptor{layer},
+	})
+	arm64, _ := pushJSON(t, store, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ocispec.Descriptor{layer},
+	})
+	amd64.Platform = &ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	arm64.Platform = &ocispec.Platform{OS: "linux", Architecture: "arm64"}
+	index, indexData := pushJSON(t, store, ocispec.MediaTypeImageIndex, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{amd64, arm64},
+	})
+	referrer, _ := pushJSON(t, store, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "test/sig",
+		Config:       ocispec.DescriptorEmptyJSON,
+		Layers:       []ocispec.Descriptor{ocispec.DescriptorEmptyJSON},
+		Subject:      &index,
+	})
+	if err := store.Push(ctx, ocispec.DescriptorEmptyJSON, bytes.NewReader(ocispec.DescriptorEmptyJSON.Data)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+
+	var buf bytes.Buffer
+	handler, err := display.NewInspectHandler(&buf, option.Format{Type: option.FormatTypeJSON.Name}, "test", index, nil)
+	if err != nil {
+		t.Fatal("NewInspectHandler() error =", err)
+	}
+	if err := inspectManifest(ctx, store, index, indexData, handler); err != nil {
+		t.Fatal("inspectManifest() error =", err)
+	}
+	if err := inspectReferrers(ctx, store, index, handler); err != nil {
+		t.Fatal("inspectReferrers() error =", err)
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+
+	var got struct {
+		Manifests []struct {
+			Digest       digest.Digest
+			ArtifactType string
+			Platform     *ocispec.Platform
+			Config       struct {
+				Author   string
+				Platform *ocispec.Platform
+			}
+			Layers []ocispec.Descriptor
+		}
+		TotalSize int64
+		Referrers []struct {
+			Digest       digest.Digest
+			ArtifactType string
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Manifests) != 2 {
+		t.Fatalf("got %d manifests, want 2", len(got.Manifests))
+	}
+	if m := got.Manifests[0]; m.Digest != amd64.Digest || m.Platform.Architecture != "amd64" || m.ArtifactType != ocispec.MediaTypeEmptyJSON || m.Config.Platform != nil || len(m.Layers) != 1 {
+		t.Errorf("got manifest %+v, want %v with an empty config", m, amd64.Digest)
+	}
+	if m := got.Manifests[1]; m.Digest != arm64.Digest || m.Config.Author != "test" || m.Config.Platform.Architecture != "arm64" {
+		t.Errorf("got manifest %+v, want %v with the image config", m, arm64.Digest)
+	}
+	if want := index.Size + amd64.Size + arm64.Size + ocispec.DescriptorEmptyJSON.Size + config.Size + layer.Size; got.TotalSize != want {
+		t.Errorf("got total size %d, want %d", got.TotalSize, want)
+	}
+	if len(got.Referrers) != 1 || got.Referrers[0].Digest != referrer.Digest || got.Referrers[0].ArtifactType != "test/sig" {
+		t.Errorf("got referrers %+v, want %v", got.Referrers, referrer.Digest)
+	}
+}
+
+func Test_resolveInspected(t *testing.T) {
+	ctx := context.Background()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal("oci.New() error =", err)
+	}
+	manifest, _ := pushJSON(t, store, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    ocispec.DescriptorEmptyJSON,
+		Layers:    []ocispec.Descriptor{ocispec.DescriptorEmptyJSON},
+	})
+	manifest.Platform = &ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	index, _ := pushJSON(t, store, ocispec.MediaTypeImageIndex, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{manifest},
+	})
+	if err := store.Tag(ctx, index, "v1"); err != nil {
+		t.Fatal("Tag() error =", err)
+	}
+
+	if err := store.Tag(ctx, manifest, "v1-amd64"); err != nil {
+		t.Fatal("Tag() error =", err)
+	}
+
+	desc, err := resolveInspected(ctx, store, "v1", nil)
+	if err != nil {
+		t.Fatal("resolveInspected() error =", err)
+	}
+	if desc.Digest != index.Digest {
+		t.Errorf("resolveInspected() = %v, want %v", desc.Digest, index.Digest)
+	}
+	desc, err = resolveInspected(ctx, store, "v1", manifest.Platform)
+	if err != nil {
+		t.Fatal("resolveInspected() error =", err)
+	}
+	if desc.Digest != manifest.Digest {
+		t.Errorf("resolveInspected() = %v, want %v", desc.Digest, manifest.Digest)
+	}
+	// the tags are looked up for the platform manifest
+	tags, err := findTags(ctx, store, logrus.New())
+	if err != nil {
+		t.Fatal("findTags() error =", err)
+	}
+	if got := tags[desc.Digest]; !reflect.DeepEqual(got, []string{"v1-amd64"}) {
+		t.Errorf("tags of the platform manifest = %v, want [v1-amd64]", got)
+	}
+}
+
+func Test_fetchImageConfig_tooLarge(t *testing.T) {
+	config := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageConfig,
+		Digest:    digest.FromString("large"),
+		Size:      maxInspectedConfigSize + 1,
+	}
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal("oci.New() error =", err)
+	}
+	image, err := fetchImageConfig(context.Background(), store, config)
+	if err != nil || image != nil {
+		t.Errorf("fetchImageConfig() = %v, %v, want nil", image, err)
+	}
+}
@@ -28,9 +28,11 @@ func Cmd() *cobra.Command {
 
 	cmd.AddCommand(
 		deleteCmd(),
+		diffCmd(),
 		fetchCmd(),
 		fetchConfigCmd(),
 		pushCmd(),
+		validateCmd(),
 		index.Cmd(),
 	)
 	return cmd
@@ -0,0 +1,168 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/file"
+)
+
+type diffOptions struct {
+	option.Common
+	option.Platform
+	option.BinaryTarget
+	option.Format
+}
+
+func diffCmd() *cobra.Command {
+	var opts diffOptions
+	cmd := &cobra.Command{
+		Use:   "diff [flags] <name1>{:<tag>|@<digest>}|<file1> <name2>{:<tag>|@<digest>}|<file2>",
+		Short: "[Preview] Compare two manifests or indexes",
+		Long: `[Preview] Compare two manifests or indexes
+
+The manifests are read from registries, OCI image layouts or local files. Local
+files are specified by paths that are absolute or start with "./" or "../",
+or "-" for stdin.
+
+The changes of the media type, the artifact type, the config, the subject and
+the annotations are displayed, along with the layers added, removed and
+changed, matched by their titles. For indexes, the child manifests added,
+removed and changed are displayed per platform, along with the differences
+between the changed child manifests unless read from local files.
+
+** This command is in preview and under development. **
+
+Example - Compare the manifests tagged 'v1' and 'v2' in repository 'localhost:5000/hello':
+  oras manifest diff localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Compare the linux/amd64 manifests of the multi-arch images tagged 'v1' and 'v2':
+  oras manifest diff --platform linux/amd64 localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Compare a local manifest file with the manifest tagged 'v1', and output in JSON format:
+  oras manifest diff --format json ./manifest.json localhost:5000/hello:v1
+
+Example - Compare the manifest read from stdin with the manifest tagged 'v1':
+  oras manifest diff - localhost:5000/hello:v1
+
+Example - Compare the manifest tagged 'v1' in an OCI image layout folder 'layout-dir' with the manifest tagged 'v1' in a registry:
+  oras manifest diff --from-oci-layout layout-dir:v1 localhost:5000/hello:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(2), "the two manifests to compare"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if args[0] == "-" && args[1] == "-" {
+				return errors.New("only one manifest can be read from stdin")
+			}
+			if args[0] == "-" || args[1] == "-" {
+				if err := option.CheckStdinConflict(cmd.Flags()); err != nil {
+					return err
+				}
+			}
+			opts.From.RawReference = args[0]
+			opts.To.RawReference = args[1]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd, &opts)
+		},
+	}
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON.WithUsage("Get the differences and output in JSON format"))
+	opts.EnableFileSource()
+	opts.EnableDistributionSpecFlag()
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.BinaryTarget)
+}
+
+func runDiff(cmd *cobra.Command, opts *diffOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewManifestDiffHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	from, fromContent, fromTarget, err := fetchManifestSource(ctx, cmd, &opts.From, opts.Common, opts.Platform, false, logger)
+	if err != nil {
+		return err
+	}
+	to, toContent, toTarget, err := fetchManifestSource(ctx, cmd, &opts.To, opts.Common, opts.Platform, false, logger)
+	if err != nil {
+		return err
+	}
+	// child manifests are not compared for local files
+	var fromFetcher, toFetcher content.Fetcher
+	if fromTarget != nil && toTarget != nil {
+		fromFetcher, toFetcher = fromTarget, toTarget
+	}
+	diff, err := manifest.CompareGraphs(ctx, fromContent, fromFetcher, toContent, toFetcher)
+	if err != nil {
+		return err
+	}
+	return handler.OnCompared(&opts.BinaryTarget, from, to, diff)
+}
+
+// fetchManifestSource fetches the manifest or the index from the target, or
+// reads it from the local file if the target is a file. The returned
+// read-only target is nil for a local file. The media type of a local file
+// failing to be extracted is left empty if allowMalformed is true, so that
+// the malformed content is reported by the caller.
+func fetchManifestSource(ctx context.Context, cmd *cobra.Command, target *option.Target, common option.Common, platform option.Platform, allowMalformed bool, logger logrus.FieldLogger) (ocispec.Descriptor, []byte, oras.ReadOnlyTarget, error) {
+	if target.Type == option.TargetTypeFile {
+		if platform.Platform != nil {
+			return ocispec.Descriptor{}, nil, nil, fmt.Errorf("`--platform` cannot be used with the local file %q", target.Path)
+		}
+		contentBytes, err := file.PrepareManifestContent(target.Path)
+		if err != nil {
+			return ocispec.Descriptor{}, nil, nil, err
+		}
+		mediaType, err := manifest.ExtractMediaType(contentBytes)
+		if err != nil && !allowMalformed && !errors.Is(err, manifest.ErrMediaTypeNotFound) {
+			return ocispec.Descriptor{}, nil, nil, fmt.Errorf("%q: %w", target.Path, err)
+		}
+		return content.NewDescriptorFromBytes(mediaType, contentBytes), contentBytes, nil, nil
+	}
+
+	src, err := target.NewReadonlyTarget(ctx, common, logger)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, nil, err
+	}
+	if err := target.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return ocispec.Descriptor{}, nil, nil, err
+	}
+	fetchOpts := oras.DefaultFetchBytesOptions
+	fetchOpts.TargetPlatform = platform.Platform
+	desc, contentBytes, err := oras.FetchBytes(ctx, src, target.Reference, fetchOpts)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, nil, fmt.Errorf("failed to fetch the content of %q: %w", target.RawReference, err)
+	}
+	if !descriptor.IsManifest(desc) {
+		return ocispec.Descriptor{}, nil, nil, fmt.Errorf("%q is not a manifest or an index: unsupported media type %q", target.RawReference, desc.MediaType)
+	}
+	return desc, contentBytes, src, nil
+}
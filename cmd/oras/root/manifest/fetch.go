@@ -61,6 +61,9 @@ Example - [Experimental] Fetch the manifest digest from a registry similar to th
 Example - [Experimental] Fetch manifest and output metadata encoded in JSON:
   oras manifest fetch localhost:5000/hello:v1 --format json
 
+Example - Fetch manifest from the local cache without accessing the registry:
+  oras manifest fetch --cache ~/.oras/cache --offline localhost:5000/hello:v1
+
 Example - Fetch manifest from a registry with specified media type:
   oras manifest fetch --media-type 'application/vnd.oci.image.manifest.v1+json' localhost:5000/hello:v1
 
@@ -98,7 +101,7 @@ Example - Fetch raw manifest tagged 'example.com:v1' from an OCI image layout fo
 		},
 		Aliases: []string{"get", "show"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fetchManifest(cmd, &opts)
+			return opts.CheckCacheMiss(fetchManifest(cmd, &opts))
 		},
 	}
 
@@ -110,6 +113,7 @@ Example - Fetch raw manifest tagged 'example.com:v1' from an OCI image layout fo
 		option.FormatTypeGoTemplate.WithUsage("Print using the given Go template"),
 	)
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
+	opts.EnableOfflineFlags()
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
 }
@@ -128,13 +132,15 @@ func fetchManifest(cmd *cobra.Command, opts *fetchOptions) (fetchErr error) {
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
+	var src oras.ReadOnlyTarget
 	if repo, ok := target.(*remote.Repository); ok {
 		repo.ManifestMediaTypes = opts.mediaTypes
+		src, err = opts.CachedRepositoryTarget(target, opts.Path)
 	} else if opts.mediaTypes != nil {
 		return fmt.Errorf("`--media-type` cannot be used with `--oci-layout` at the same time")
+	} else {
+		src, err = opts.CachedTarget(target)
 	}
-
-	src, err := opts.CachedTarget(target)
 	if err != nil {
 		return err
 	}
@@ -48,6 +48,7 @@ type pushOptions struct {
 	extraRefs   []string
 	fileRef     string
 	mediaType   string
+	validate    bool
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -84,6 +85,9 @@ Example - Push a manifest to repository 'localhost:5000/hello' and tag with 'tag
 Example - Push a manifest to repository 'localhost:5000/hello' and tag with 'tag1', 'tag2', 'tag3' and concurrency level tuned:
   oras manifest push --concurrency 6 localhost:5000/hello:tag1,tag2,tag3 manifest.json
 
+Example - Validate a manifest against the image-spec before pushing it:
+  oras manifest push --validate localhost:5000/hello:v1 manifest.json
+
 Example - Push a manifest to an OCI image layout folder 'layout-dir' and tag with 'v1':
   oras manifest push --oci-layout layout-dir:v1 manifest.json
 
@@ -113,6 +117,7 @@ Example - Push a manifest to an OCI image layout folder 'layout-dir' and tag wit
 	option.ApplyFlags(&opts, cmd.Flags())
 	cmd.Flags().StringVarP(&opts.mediaType, "media-type", "", "", "media type of manifest")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	cmd.Flags().BoolVarP(&opts.validate, "validate", "", false, "[Preview] validate the manifest against the image-spec and fail the push on errors")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	return oerrors.Command(cmd, &opts.Target)
@@ -126,6 +131,8 @@ func pushManifest(cmd *cobra.Command, opts pushOptions) error {
 	if err != nil {
 		return err
 	}
+	// referenced contents are resolved from the whole repository
+	resolve := newDescriptorResolver(target)
 	if repo, ok := target.(*remote.Repository); ok {
 		target = repo.Manifests()
 	}
@@ -154,7 +161,27 @@ func pushManifest(cmd *cobra.Command, opts pushOptions) error {
 
 	// prepare manifest descriptor
 	desc := content.NewDescriptorFromBytes(mediaType, contentBytes)
-	statusHandler, metadataHandler := display.NewManifestPushHandler(opts.Printer, opts.OutputDescriptor, opts.Pretty.Pretty, desc, &opts.Target)
+	statusHandler, metadataHandler, validateHandler := display.NewManifestPushHandler(opts.Printer, opts.OutputDescriptor, opts.Pretty.Pretty, desc, &opts.Target)
+
+	if opts.validate {
+		findings, err := manifest.Validate(ctx, contentBytes, mediaType, resolve)
+		if err != nil {
+			return err
+		}
+		if err := validateHandler.OnValidated(&opts.Target, desc, findings); err != nil {
+			return err
+		}
+		if err := checkFindings(findings); err != nil {
+			recommendation := `Please fix the errors in the manifest, or push it without the flag "--validate"`
+			if opts.OutputDescriptor {
+				recommendation = `Please run "oras manifest validate" to see the errors in the manifest, or push it without the flag "--validate"`
+			}
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: recommendation,
+			}
+		}
+	}
 
 	ref := opts.Reference
 	if ref == "" {
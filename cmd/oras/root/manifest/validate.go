@@ -0,0 +1,161 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
+)
+
+type validateOptions struct {
+	option.Common
+	option.Platform
+	option.Target
+	option.Format
+
+	mediaType string
+}
+
+func validateCmd() *cobra.Command {
+	var opts validateOptions
+	cmd := &cobra.Command{
+		Use:   "validate [flags] <name>{:<tag>|@<digest>}|<file>",
+		Short: "[Preview] Validate a manifest or an index against the image-spec",
+		Long: `[Preview] Validate a manifest or an index against the image-spec
+
+The manifest is read from a registry, an OCI image layout or a local file, and
+checked for the conformity to the schema, the format of the descriptor fields,
+the artifact guidance of the image-spec v1.0 and v1.1, and the conventions of
+the annotation keys. For manifests in a registry or an OCI image layout, the
+digests and the sizes of the referenced contents are also checked. Local files
+are specified by paths that are absolute or start with "./" or "../", or "-"
+for stdin.
+
+The findings are reported with the severities error, warning and info. The
+command fails if any error is found.
+
+** This command is in preview and under development. **
+
+Example - Validate the manifest tagged 'v1' in repository 'localhost:5000/hello':
+  oras manifest validate localhost:5000/hello:v1
+
+Example - Validate the linux/amd64 manifest of the multi-arch image tagged 'v1':
+  oras manifest validate --platform linux/amd64 localhost:5000/hello:v1
+
+Example - Validate a local manifest file and output in JSON format:
+  oras manifest validate --format json ./manifest.json
+
+Example - Validate a local manifest file without the media type field:
+  oras manifest validate --media-type application/vnd.oci.image.manifest.v1+json ./manifest.json
+
+Example - Validate a manifest read from stdin:
+  oras manifest validate -
+
+Example - Validate the manifest tagged 'v1' in an OCI image layout folder 'layout-dir':
+  oras manifest validate --oci-layout layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the manifest to validate"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if args[0] == "-" {
+				if err := option.CheckStdinConflict(cmd.Flags()); err != nil {
+					return err
+				}
+			}
+			opts.RawReference = args[0]
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			if opts.mediaType != "" && opts.Target.Type != option.TargetTypeFile {
+				return errors.New("`--media-type` can only be used with a local file")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runValidate(cmd, &opts)
+		},
+	}
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON.WithUsage("Get the findings and output in JSON format"))
+	opts.EnableFileSource()
+	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.Flags().StringVarP(&opts.mediaType, "media-type", "", "", "media type of the manifest in a local file")
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runValidate(cmd *cobra.Command, opts *validateOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewManifestValidateHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	desc, contentBytes, src, err := fetchManifestSource(ctx, cmd, &opts.Target, opts.Common, opts.Platform, true, logger)
+	if err != nil {
+		return err
+	}
+	if opts.mediaType != "" {
+		desc = content.NewDescriptorFromBytes(opts.mediaType, contentBytes)
+	}
+	var resolve manifest.DescriptorResolver
+	if src != nil {
+		resolve = newDescriptorResolver(src)
+	}
+	findings, err := manifest.Validate(ctx, contentBytes, desc.MediaType, resolve)
+	if err != nil {
+		return err
+	}
+	if err := handler.OnValidated(&opts.Target, desc, findings); err != nil {
+		return err
+	}
+	return checkFindings(findings)
+}
+
+// newDescriptorResolver returns a resolver resolving the contents referenced
+// by a manifest from the target.
+func newDescriptorResolver(target oras.ReadOnlyTarget) manifest.DescriptorResolver {
+	return func(ctx context.Context, desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+		resolver := content.Resolver(target)
+		if repo, ok := target.(*remote.Repository); ok {
+			// the repository only resolves manifests by default
+			if descriptor.IsManifest(desc) {
+				resolver = repo.Manifests()
+			} else {
+				resolver = repo.Blobs()
+			}
+		}
+		return resolver.Resolve(ctx, desc.Digest.String())
+	}
+}
+
+// checkFindings returns an error if any of the findings is an error.
+func checkFindings(findings []manifest.Finding) error {
+	if count := manifest.Count(findings, manifest.SeverityError); count > 0 {
+		return fmt.Errorf("manifest validation failed with %d error(s)", count)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+func Test_runValidate_errType(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	opts := &validateOptions{
+		Format: option.Format{
+			Type: "unknown",
+		},
+	}
+	got := runValidate(cmd, opts).Error()
+	want := oerrors.UnsupportedFormatTypeError(opts.Format.Type).Error()
+	if got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func Test_newDescriptorResolver(t *testing.T) {
+	ctx := context.Background()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob := []byte("foo")
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	if err := store.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal(err)
+	}
+	resolve := newDescriptorResolver(store)
+
+	got, err := resolve(ctx, desc)
+	if err != nil {
+		t.Fatal("resolve() error =", err)
+	}
+	if got.Digest != desc.Digest || got.Size != desc.Size {
+		t.Errorf("resolve() = %v, want %v", got, desc)
+	}
+	missing := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    digest.FromString("missing"),
+		Size:      7,
+	}
+	if _, err := resolve(ctx, missing); !errors.Is(err, errdef.ErrNotFound) {
+		t.Errorf("resolve() error = %v, wantErr %v", err, errdef.ErrNotFound)
+	}
+}
+
+func Test_checkFindings(t *testing.T) {
+	findings := []manifest.Finding{
+		{Severity: manifest.SeverityWarning, Field: "mediaType", Message: "should be set"},
+		{Severity: manifest.SeverityInfo, Field: "config.mediaType", Message: "is used as the artifact type"},
+	}
+	if err := checkFindings(findings); err != nil {
+		t.Errorf("checkFindings() error = %v, want nil", err)
+	}
+	findings = append(findings, manifest.Finding{Severity: manifest.SeverityError, Field: "config", Message: "is required"})
+	if err := checkFindings(findings); err == nil {
+		t.Error("checkFindings() error = nil, want error")
+	}
+}
+
+func Test_fetchManifestSource_malformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := os.WriteFile(path, []byte(`{"mediaType":1}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := &cobra.Command{}
+	target := &option.Target{Type: option.TargetTypeFile, Path: path}
+
+	// malformed content fails diff
+	if _, _, _, err := fetchManifestSource(context.Background(), cmd, target, option.Common{}, option.Platform{}, false, nil); !errors.Is(err, manifest.ErrInvalidJSON) {
+		t.Errorf("fetchManifestSource() error = %v, want %v", err, manifest.ErrInvalidJSON)
+	}
+	// malformed content is left to be reported by validate
+	_, data, _, err := fetchManifestSource(context.Background(), cmd, target, option.Common{}, option.Platform{}, true, nil)
+	if err != nil {
+		t.Fatal("fetchManifestSource() error =", err)
+	}
+	if string(data) != `{"mediaType":1}` {
+		t.Errorf("fetchManifestSource() content = %s, want %s", data, `{"mediaType":1}`)
+	}
+}
@@ -17,15 +17,25 @@ package root
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"text/template"
+	"time"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
@@ -34,8 +44,12 @@ import (
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/fileref"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/chunk"
 	"oras.land/oras/internal/descriptor"
+	orasfile "oras.land/oras/internal/file"
 	"oras.land/oras/internal/graph"
+	"oras.land/oras/internal/pathfilter"
+	"oras.land/oras/internal/resume"
 )
 
 type pullOptions struct {
@@ -48,10 +62,31 @@ type pullOptions struct {
 
 	concurrency       int
 	KeepOldFiles      bool
+	SkipExisting      bool
 	IncludeSubject    bool
+	includeReferrers  bool
+	referrerType      string
+	latestReferrer    bool
+	allPlatforms      bool
+	platformPath      string
 	PathTraversal     bool
+	atomic            bool
+	replaceDir        bool
 	Output            string
 	ManifestConfigRef string
+	includePatterns   []string
+	excludePatterns   []string
+	mediaTypes        []string
+	filter            *pathfilter.Filter
+	// platformPathTemplate is parsed from platformPath.
+	platformPathTemplate *template.Template
+	// outputRoot and stagingRoot are the root output directory and its
+	// staging directory for atomic pulls.
+	outputRoot  string
+	stagingRoot string
+	// preservePermissions restores the permission bits of the files in the
+	// extracted directories even if they are not recorded on push.
+	preservePermissions bool
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -63,12 +98,36 @@ func pullCmd() *cobra.Command {
 		Short: "Pull files from a registry or an OCI image layout",
 		Long: `Pull files from a registry or an OCI image layout
 
+Interrupted downloads of large files from a registry are resumed on the next
+pull into the same output directory if the registry supports range requests.
+The downloads are tracked in the directory ".oras-partial" under the output
+directory. Downloads with "--atomic" or "--replace-dir" are not resumed as the
+staged files are discarded on failure.
+
+With "--atomic", each staged file is moved into the output directory with a
+single rename once all files are pulled, but the files are not moved as a
+whole atomically. With "--replace-dir", the output directory is swapped with
+the staging directory in a single step, which requires support from the
+platform and the file system, such as Linux and macOS.
+
+The permission bits and modification times of files pushed with
+"--preserve-permissions" are restored, except the setuid, setgid and sticky
+bits, which are never set on pulled files or extracted directories. Use
+"--preserve-permissions" to restore the permission bits of the files in
+directories pushed without it.
+
 Example - Pull artifact files from a registry:
   oras pull localhost:5000/hello:v1
 
 Example - Recursively pulling all files from a registry, including subjects of hello:v1:
   oras pull --include-subject localhost:5000/hello:v1
 
+Example - Pull files together with the SBOMs attached to hello:v1:
+  oras pull --include-referrers --referrer-type application/spdx+json localhost:5000/hello:v1
+
+Example - Pull files together with the latest SBOM attached to hello:v1:
+  oras pull --include-referrers --referrer-type application/spdx+json --latest-referrer localhost:5000/hello:v1
+
 Example - Pull files from an insecure registry:
   oras pull --insecure localhost:5000/hello:v1
 
@@ -79,9 +138,36 @@ Example - Pull files from a registry with local cache:
   export ORAS_CACHE=~/.oras/cache
   oras pull localhost:5000/hello:v1
 
+Example - Pull files from a registry with local cache, resolving tags cached within the last 10 minutes:
+  oras pull --cache ~/.oras/cache --cache-ttl 10m localhost:5000/hello:v1
+
+Example - Pull files from the local cache without accessing the registry:
+  oras pull --cache ~/.oras/cache --offline localhost:5000/hello:v1
+
 Example - Pull files from a registry with certain platform:
   oras pull --platform linux/arm/v5 localhost:5000/hello:v1
 
+Example - Pull all platforms of a multi-arch artifact into 'out/<os>_<arch>[_<variant>]':
+  oras pull --all-platforms -o out localhost:5000/hello:v1
+
+Example - Pull all platforms of a multi-arch artifact into 'out/<os>/<arch>':
+  oras pull --all-platforms --platform-path "{{.OS}}/{{.Architecture}}" -o out localhost:5000/hello:v1
+
+Example - Pull only the linux binaries except debug symbols:
+  oras pull --include "bin/linux-*" --exclude "*.pdb" localhost:5000/hello:v1
+
+Example - Pull only the files of media type 'application/x-foo':
+  oras pull --media-type application/x-foo localhost:5000/hello:v1
+
+Example - Pull only the files changed since the last pull into the directory 'cache':
+  oras pull --skip-existing -o cache localhost:5000/hello:v1
+
+Example - Pull files into the directory 'app' only if all files are pulled successfully:
+  oras pull --atomic -o app localhost:5000/hello:v1
+
+Example - Replace the directory 'app' with the pulled files only if all files are pulled successfully:
+  oras pull --replace-dir -o app localhost:5000/hello:v1
+
 Example - Pull all files with concurrency level tuned:
   oras pull --concurrency 6 localhost:5000/hello:v1
 
@@ -107,29 +193,74 @@ Example - Pull artifact files tagged 'example.com:v1' from an OCI image layout f
 			if err != nil {
 				return err
 			}
+			if opts.filter, err = pathfilter.New(opts.includePatterns, opts.excludePatterns); err != nil {
+				return err
+			}
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "platform", "all-platforms"); err != nil {
+				return err
+			}
+			opts.atomic = opts.atomic || opts.replaceDir
+			for _, flags := range [][]string{
+				{"replace-dir", "skip-existing"},
+				{"replace-dir", "keep-old-files"},
+				{"atomic", "allow-path-traversal"},
+				{"replace-dir", "allow-path-traversal"},
+				{"offline", "include-referrers"},
+			} {
+				if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), flags...); err != nil {
+					return err
+				}
+			}
+			if opts.replaceDir {
+				if err := checkReplaceDir(cmd, opts.Output); err != nil {
+					return err
+				}
+			}
+			if !opts.includeReferrers && (opts.referrerType != "" || opts.latestReferrer) {
+				return &oerrors.Error{
+					Err:            errors.New("`--referrer-type` and `--latest-referrer` can only be used with `--include-referrers`"),
+					Recommendation: "add `--include-referrers` to pull the referrers",
+				}
+			}
+			if opts.platformPathTemplate, err = template.New("platform path").Parse(opts.platformPath); err != nil {
+				return fmt.Errorf("invalid platform path %q: %w", opts.platformPath, err)
+			}
 			opts.DisableTTY(opts.Debug, false)
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Printer.Verbose = opts.verbose
-			return runPull(cmd, &opts)
+			return opts.CheckCacheMiss(runPull(cmd, &opts))
 		},
 	}
 
 	cmd.Flags().BoolVarP(&opts.KeepOldFiles, "keep-old-files", "k", false, "do not replace existing files when pulling, treat them as errors")
+	cmd.Flags().BoolVarP(&opts.SkipExisting, "skip-existing", "", false, "skip downloading files already present in the output directory with matching digests")
 	cmd.Flags().BoolVarP(&opts.PathTraversal, "allow-path-traversal", "T", false, "allow storing files out of the output directory")
+	cmd.Flags().BoolVarP(&opts.atomic, "atomic", "", false, "download into a staging directory and move the files into the output directory only if all files are pulled and verified")
+	cmd.Flags().BoolVarP(&opts.replaceDir, "replace-dir", "", false, "replace the output directory specified by --output as a whole with the pulled files, implies --atomic")
+	cmd.Flags().BoolVarP(&opts.preservePermissions, "preserve-permissions", "", false, "restore the permission bits of the files in pulled directories, which is the default for directories pushed with --preserve-permissions")
 	cmd.Flags().BoolVarP(&opts.IncludeSubject, "include-subject", "", false, "recursively pull the subject of artifacts")
+	cmd.Flags().BoolVarP(&opts.includeReferrers, "include-referrers", "", false, "pull the files of the referrers of the artifact into per-referrer directories named after their digests")
+	cmd.Flags().StringVarP(&opts.referrerType, "referrer-type", "", "", "only pull the referrers of the artifact `type`, used with --include-referrers")
+	cmd.Flags().BoolVarP(&opts.latestReferrer, "latest-referrer", "", false, "only pull the referrer created the latest, used with --include-referrers")
 	cmd.Flags().StringVarP(&opts.Output, "output", "o", ".", "output directory")
+	cmd.Flags().BoolVarP(&opts.allPlatforms, "all-platforms", "", false, "pull the files of all platforms of a multi-platform artifact into per-platform directories, skipping attestation manifests")
+	cmd.Flags().StringVarP(&opts.platformPath, "platform-path", "", "{{.OS}}_{{.Architecture}}{{with .Variant}}_{{.}}{{end}}", "Go template of the per-platform directory `path` under the output directory, used with --all-platforms")
 	cmd.Flags().StringVarP(&opts.ManifestConfigRef, "config", "", "", "output manifest config file")
+	cmd.Flags().StringArrayVarP(&opts.includePatterns, "include", "", nil, "only pull files whose names match the glob `pattern`")
+	cmd.Flags().StringArrayVarP(&opts.excludePatterns, "exclude", "", nil, "skip files whose names match the glob `pattern`")
+	cmd.Flags().StringArrayVarP(&opts.mediaTypes, "media-type", "", nil, "only pull files of the `media type`")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	opts.EnableOfflineFlags()
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
 }
 
-func runPull(cmd *cobra.Command, opts *pullOptions) (pullError error) {
+func runPull(cmd *cobra.Command, opts *pullOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 	statusHandler, metadataHandler, err := display.NewPullHandler(opts.Printer, opts.Format, opts.Path, opts.TTY)
 	if err != nil {
@@ -148,23 +279,51 @@ func runPull(cmd *cobra.Command, opts *pullOptions) (pullError error) {
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
-	src, err := opts.CachedTarget(target)
-	if err != nil {
-		return err
+	var source oras.ReadOnlyTarget = target
+	if opts.Target.Type == option.TargetTypeRemote {
+		// interrupted downloads are resumed on the next pull
+		source = resume.New(target)
+	}
+	var src oras.ReadOnlyTarget
+	if opts.Target.Type == option.TargetTypeRemote {
+		src, err = opts.CachedRepositoryTarget(source, opts.Path)
+	} else {
+		src, err = opts.CachedTarget(source)
 	}
-	dst, err := file.New(opts.Output)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if err := dst.Close(); pullError == nil {
-			pullError = err
+	var staging *orasfile.Staging
+	if opts.atomic {
+		if staging, err = orasfile.NewStaging(opts.Output, opts.replaceDir); err != nil {
+			if errors.Is(err, orasfile.ErrReplaceUnsupported) {
+				return &oerrors.Error{
+					Err:            err,
+					Recommendation: "use `--atomic` to move the pulled files into the existing output directory instead",
+				}
+			}
+			return err
 		}
-	}()
-	dst.AllowPathTraversalOnWrite = opts.PathTraversal
-	dst.DisableOverwrite = opts.KeepOldFiles
+		defer func() { _ = staging.Discard() }()
+		opts.outputRoot, opts.stagingRoot = opts.Output, staging.Dir
+	}
 
-	desc, err := doPull(ctx, src, dst, copyOptions, metadataHandler, statusHandler, opts)
+	var desc ocispec.Descriptor
+	if opts.allPlatforms {
+		desc, err = pullAllPlatforms(ctx, src, copyOptions, metadataHandler, statusHandler, opts)
+	} else {
+		desc, err = pullToOutput(ctx, src, copyOptions, metadataHandler, statusHandler, opts)
+	}
+	if err == nil && opts.includeReferrers {
+		err = pullReferrers(ctx, target, src, desc, metadataHandler, statusHandler, opts)
+	}
+	if err == nil && staging != nil {
+		if opts.replaceDir {
+			err = staging.Replace()
+		} else if err = staging.Commit(opts.KeepOldFiles); errors.Is(err, fs.ErrExist) {
+			err = fmt.Errorf("%w: %w", file.ErrOverwriteDisallowed, err)
+		}
+	}
 	if err != nil {
 		if !errors.Is(err, file.ErrPathTraversalDisallowed) {
 			return err
@@ -179,6 +338,248 @@ func runPull(cmd *cobra.Command, opts *pullOptions) (pullError error) {
 	return metadataHandler.Render()
 }
 
+// checkReplaceDir returns an error if the output directory cannot be replaced
+// as a whole with --replace-dir.
+func checkReplaceDir(cmd *cobra.Command, output string) error {
+	if !cmd.Flags().Changed("output") {
+		return &oerrors.Error{
+			Err:            errors.New("`--replace-dir` requires the output directory to be specified"),
+			Recommendation: "specify the directory to be replaced via `--output`",
+		}
+	}
+	if err := orasfile.CheckReplaceable(output); err != nil {
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: "pull into a directory other than the current working directory and its parents, or use `--atomic` to keep the existing files",
+		}
+	}
+	return nil
+}
+
+// pullToOutput pulls the files of the artifact into the output directory.
+func pullToOutput(ctx context.Context, src oras.ReadOnlyTarget, opts oras.CopyOptions, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, po *pullOptions) (_ ocispec.Descriptor, pullError error) {
+	dir := po.storeDir(po.Output)
+	dst, err := file.New(dir)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer func() {
+		if err := dst.Close(); pullError == nil {
+			pullError = err
+		}
+	}()
+	ctx = resume.WithDir(ctx, dir)
+	defer resume.Cleanup(dir)
+	dst.AllowPathTraversalOnWrite = po.PathTraversal
+	dst.DisableOverwrite = po.KeepOldFiles
+	dst.PreservePermissions = po.preservePermissions
+	return doPull(ctx, src, newModeTarget(dst, dir), opts, metadataHandler, statusHandler, po)
+}
+
+// modeTarget is a file store restoring the permission bits in the tarballs of
+// the extracted directories which are pushed with their permission bits
+// recorded, in addition to the directories extracted with the permission bits
+// preserved by the file store itself. The modes of the extracted directories
+// are sanitized after copy.
+type modeTarget struct {
+	*file.Store
+	// dir is the working directory of the file store.
+	dir string
+}
+
+// newModeTarget returns a modeTarget wrapping the file store with the working
+// directory dir.
+func newModeTarget(store *file.Store, dir string) *modeTarget {
+	return &modeTarget{
+		Store: store,
+		dir:   dir,
+	}
+}
+
+// Push pushes the content, restoring the permission bits of the extracted
+// directory if they are recorded but not preserved by the file store.
+func (t *modeTarget) Push(ctx context.Context, desc ocispec.Descriptor, content io.Reader) error {
+	if err := t.Store.Push(ctx, desc, content); err != nil {
+		return err
+	}
+	if t.Store.PreservePermissions || desc.Annotations[file.AnnotationUnpack] != "true" {
+		return nil
+	}
+	if _, recorded := desc.Annotations[orasfile.AnnotationMode]; !recorded {
+		return nil
+	}
+	name := desc.Annotations[ocispec.AnnotationTitle]
+	dir := name
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(t.dir, name)
+	}
+	// the file store keeps the tarball of the extracted directory
+	rc, err := t.Store.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+	if err := orasfile.ApplyTarModes(rc, dir, name); err != nil {
+		return fmt.Errorf("failed to restore the permission bits of %s: %w", name, err)
+	}
+	return nil
+}
+
+// pullAllPlatforms pulls the files of each platform-specific manifest in the
+// multi-platform artifact into the platform directory under the output
+// directory.
+func pullAllPlatforms(ctx context.Context, src oras.ReadOnlyTarget, opts oras.CopyOptions, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, po *pullOptions) (ocispec.Descriptor, error) {
+	root, err := oras.Resolve(ctx, src, po.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", po.Reference, err)
+	}
+	if !descriptor.IsIndex(root) {
+		return ocispec.Descriptor{}, &oerrors.Error{
+			Err:            fmt.Errorf("%s is not a multi-platform artifact but of media type %q", po.GetDisplayReference(), root.MediaType),
+			Recommendation: "remove --all-platforms to pull the artifact",
+		}
+	}
+	indexJSON, err := content.FetchAll(ctx, src, root)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(indexJSON, &index); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to parse %s: %w", po.GetDisplayReference(), err)
+	}
+
+	dirs := make(map[string]ocispec.Descriptor)
+	for _, manifest := range index.Manifests {
+		if manifest.Platform == nil || isAttestationManifest(manifest) {
+			continue
+		}
+		dir, err := po.platformOutputDir(manifest.Platform)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if other, ok := dirs[dir]; ok {
+			return ocispec.Descriptor{}, &oerrors.Error{
+				Err:            fmt.Errorf("manifests %s and %s are pulled into the same directory %q", other.Digest, manifest.Digest, dir),
+				Recommendation: "distinguish the platforms via --platform-path, e.g. with {{.OSVersion}}",
+			}
+		}
+		dirs[dir] = manifest
+
+		if err := metadataHandler.OnPlatformPulling(manifest); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		platformOpts := *po
+		platformOpts.Output = dir
+		platformOpts.Reference = manifest.Digest.String()
+		if _, err := pullToOutput(ctx, src, opts, metadataHandler, statusHandler, &platformOpts); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	if len(dirs) == 0 {
+		return ocispec.Descriptor{}, fmt.Errorf("no platform-specific manifest found in %s", po.GetDisplayReference())
+	}
+	return root, nil
+}
+
+// annotationDockerReferenceType is the annotation BuildKit sets on the
+// manifests referring to other manifests in an index.
+const (
+	annotationDockerReferenceType  = "vnd.docker.reference.type"
+	dockerReferenceTypeAttestation = "attestation-manifest"
+)
+
+// isAttestationManifest returns true if the manifest is an attestation
+// manifest attached to the index by BuildKit, which is not runnable on any
+// platform.
+func isAttestationManifest(desc ocispec.Descriptor) bool {
+	if desc.Annotations[annotationDockerReferenceType] == dockerReferenceTypeAttestation {
+		return true
+	}
+	return desc.Platform != nil && desc.Platform.OS == "unknown" && desc.Platform.Architecture == "unknown"
+}
+
+// pullReferrers pulls the files of the referrers of the subject into the
+// referrer directories under the output directory.
+func pullReferrers(ctx context.Context, graphTarget oras.ReadOnlyGraphTarget, src oras.ReadOnlyTarget, subject ocispec.Descriptor, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, po *pullOptions) error {
+	referrers, err := registry.Referrers(ctx, graphTarget, subject, po.referrerType)
+	if err != nil {
+		return err
+	}
+	if po.latestReferrer && len(referrers) > 0 {
+		referrers = []ocispec.Descriptor{latestReferrer(referrers)}
+	}
+
+	// referrers are pulled as a whole regardless of the target platform
+	copyOptions := oras.DefaultCopyOptions
+	copyOptions.Concurrency = po.concurrency
+	for _, referrer := range referrers {
+		if err := referrer.Digest.Validate(); err != nil {
+			return fmt.Errorf("invalid referrer %q: %w", referrer.Digest, err)
+		}
+		if err := metadataHandler.OnReferrerPulling(referrer); err != nil {
+			return err
+		}
+		referrerOpts := *po
+		referrerOpts.Output = filepath.Join(po.Output, referrer.Digest.Algorithm().String()+"-"+referrer.Digest.Encoded())
+		referrerOpts.Reference = referrer.Digest.String()
+		referrerOpts.IncludeSubject = false
+		if _, err := pullToOutput(ctx, src, copyOptions, metadataHandler, statusHandler, &referrerOpts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// latestReferrer returns the referrer created the latest according to the
+// annotation "org.opencontainers.image.created". Referrers without a valid
+// creation time are considered the oldest.
+func latestReferrer(referrers []ocispec.Descriptor) ocispec.Descriptor {
+	var latest ocispec.Descriptor
+	var latestTime time.Time
+	for i, referrer := range referrers {
+		created, _ := descriptor.CreatedTime(referrer)
+		if i == 0 || created.After(latestTime) {
+			latest, latestTime = referrer, created
+		}
+	}
+	return latest
+}
+
+// storeDir returns the path to write for the path under the output
+// directory, which is in the staging directory for atomic pulls.
+func (po *pullOptions) storeDir(path string) string {
+	if po.stagingRoot == "" {
+		return path
+	}
+	root, err := filepath.Abs(po.outputRoot)
+	if err != nil {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		// not likely to happen since path traversal is disallowed
+		return path
+	}
+	return filepath.Join(po.stagingRoot, rel)
+}
+
+// platformOutputDir returns the output directory of the platform.
+func (po *pullOptions) platformOutputDir(platform *ocispec.Platform) (string, error) {
+	var sb strings.Builder
+	if err := po.platformPathTemplate.Execute(&sb, platform); err != nil {
+		return "", fmt.Errorf("failed to render the platform path: %w", err)
+	}
+	name := sb.String()
+	if name == "" {
+		return "", fmt.Errorf("empty platform path rendered for %s/%s", platform.OS, platform.Architecture)
+	}
+	return resolveOutputPath(po.Output, name, po.PathTraversal)
+}
+
 func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget, opts oras.CopyOptions, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, po *pullOptions) (ocispec.Descriptor, error) {
 	var configPath, configMediaType string
 	var err error
@@ -197,7 +598,10 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 		_ = stopTrack()
 	}()
 	var printed sync.Map
+	var skippedFiles sync.Map
 	var getConfigOnce sync.Once
+	var chunksLock sync.Mutex
+	var chunks []ocispec.Descriptor
 	opts.FindSuccessors = func(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
 		statusFetcher := content.FetcherFunc(func(ctx context.Context, target ocispec.Descriptor) (fetched io.ReadCloser, fetchErr error) {
 			if _, ok := printed.LoadOrStore(descriptor.GenerateContentKey(target), true); ok {
@@ -222,6 +626,18 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 		if err != nil {
 			return nil, err
 		}
+		nodes, err = po.filterFiles(nodes, func(name string, s ocispec.Descriptor) error {
+			if err := notifyOnce(&printed, s, statusHandler.OnNodeSkipped); err != nil {
+				return err
+			}
+			if _, loaded := skippedFiles.LoadOrStore(name, true); loaded {
+				return nil
+			}
+			return metadataHandler.OnFileSkipped(name, s, po.Path)
+		})
+		if err != nil {
+			return nil, err
+		}
 		if subject != nil && po.IncludeSubject {
 			nodes = append(nodes, *subject)
 		}
@@ -241,6 +657,28 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 
 		var ret []ocispec.Descriptor
 		for _, s := range nodes {
+			if chunk.IsChunk(s) {
+				// chunks are reassembled after copy
+				chunksLock.Lock()
+				chunks = append(chunks, s)
+				chunksLock.Unlock()
+				continue
+			}
+			if po.SkipExisting {
+				exists, err := po.existsInOutput(s)
+				if err != nil {
+					return nil, err
+				}
+				if exists {
+					// existing files are left out of the pulled files and
+					// the files filtered out
+					if err := notifyOnce(&printed, s, statusHandler.OnNodeExists); err != nil {
+						return nil, err
+					}
+					skippedFiles.Store(s.Annotations[ocispec.AnnotationTitle], true)
+					continue
+				}
+			}
 			if s.Annotations[ocispec.AnnotationTitle] == "" {
 				if content.Equal(s, ocispec.DescriptorEmptyJSON) {
 					// empty layer
@@ -280,6 +718,12 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 		}
 		for _, s := range successors {
 			if name, ok := s.Annotations[ocispec.AnnotationTitle]; ok {
+				if _, skipped := skippedFiles.Load(name); skipped {
+					continue
+				}
+				if err = po.applyFileMode(name, s); err != nil {
+					return err
+				}
 				if err = metadataHandler.OnFilePulled(name, po.Output, s, po.Path); err != nil {
 					return err
 				}
@@ -294,7 +738,196 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 
 	// Copy
 	desc, err := oras.Copy(ctx, src, po.Reference, dst, po.Reference, opts)
-	return desc, oerrors.UnwrapCopyError(err) // we don't need the CopyError information so we unwrap it here
+	if err != nil {
+		return ocispec.Descriptor{}, oerrors.UnwrapCopyError(err) // we don't need the CopyError information so we unwrap it here
+	}
+	if len(chunks) == 0 {
+		return desc, nil
+	}
+	return desc, pullChunkedFiles(ctx, src, chunks, metadataHandler, statusHandler, po)
+}
+
+// applyFileMode applies the permission bits and the modification time recorded
+// in the annotations to the pulled file. Directories extracted from tarballs
+// are sanitized so that no setuid, setgid or sticky bit is set.
+func (po *pullOptions) applyFileMode(name string, desc ocispec.Descriptor) error {
+	path, err := resolveOutputPath(po.Output, name, po.PathTraversal)
+	if err != nil {
+		return err
+	}
+	path = po.storeDir(path)
+	if desc.Annotations[file.AnnotationUnpack] == "true" {
+		if err := orasfile.SanitizeModes(path); err != nil {
+			return err
+		}
+	}
+	return orasfile.ApplyMode(path, desc.Annotations)
+}
+
+// filterFiles filters out the named files not matching the name patterns or
+// the media types. Unnamed nodes are kept.
+func (po *pullOptions) filterFiles(nodes []ocispec.Descriptor, onSkipped func(name string, desc ocispec.Descriptor) error) ([]ocispec.Descriptor, error) {
+	if po.filter.IsEmpty() && len(po.mediaTypes) == 0 {
+		return nodes, nil
+	}
+	ret := make([]ocispec.Descriptor, 0, len(nodes))
+	for _, node := range nodes {
+		name := node.Annotations[ocispec.AnnotationTitle]
+		if chunk.IsChunk(node) {
+			name = node.Annotations[chunk.AnnotationTitle]
+		}
+		if name == "" || !po.filter.Excluded(name, false) && (len(po.mediaTypes) == 0 || slices.Contains(po.mediaTypes, node.MediaType)) {
+			ret = append(ret, node)
+			continue
+		}
+		if err := onSkipped(name, node); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// existsInOutput returns true if the named file described by desc is already
+// present in the output directory. Directories are never considered present.
+func (po *pullOptions) existsInOutput(desc ocispec.Descriptor) (bool, error) {
+	name := desc.Annotations[ocispec.AnnotationTitle]
+	if name == "" || desc.Annotations[file.AnnotationUnpack] == "true" {
+		return false, nil
+	}
+	path, err := resolveOutputPath(po.Output, name, po.PathTraversal)
+	if err != nil {
+		// leave the error to the file store
+		return false, nil
+	}
+	return fileMatches(path, desc)
+}
+
+// fileMatches returns true if the regular file at path has the size and the
+// digest of desc.
+func fileMatches(path string, desc ocispec.Descriptor) (bool, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !fi.Mode().IsRegular() || fi.Size() != desc.Size || desc.Digest.Validate() != nil {
+		return false, nil
+	}
+	fp, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer fp.Close()
+	digester := desc.Digest.Algorithm().Digester()
+	if _, err := io.Copy(digester.Hash(), fp); err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return digester.Digest() == desc.Digest, nil
+}
+
+// pullChunkedFiles reassembles the files split into chunks.
+func pullChunkedFiles(ctx context.Context, src content.Fetcher, chunks []ocispec.Descriptor, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, po *pullOptions) error {
+	files, err := chunk.Group(chunks)
+	if err != nil {
+		return err
+	}
+	onChunk := func(c ocispec.Descriptor, done bool) error {
+		if done {
+			return statusHandler.OnNodeDownloaded(c)
+		}
+		return statusHandler.OnNodeDownloading(c)
+	}
+	for _, f := range files {
+		name := f.Descriptor.Annotations[ocispec.AnnotationTitle]
+		path, err := resolveOutputPath(po.Output, name, po.PathTraversal)
+		if err != nil {
+			return err
+		}
+		if po.SkipExisting {
+			exists, err := fileMatches(path, f.Descriptor)
+			if err != nil {
+				return err
+			}
+			if exists {
+				if err := statusHandler.OnNodeExists(f.Descriptor); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if po.KeepOldFiles {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%q: %w", name, file.ErrOverwriteDisallowed)
+			}
+		}
+		if err := assembleFile(ctx, src, f, po.storeDir(path), onChunk); err != nil {
+			return err
+		}
+		if err := orasfile.ApplyMode(po.storeDir(path), f.Chunks[0].Annotations); err != nil {
+			return err
+		}
+		if err := statusHandler.OnNodeDownloaded(f.Descriptor); err != nil {
+			return err
+		}
+		if err := metadataHandler.OnFilePulled(name, po.Output, f.Descriptor, po.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// partialFileSuffix is the suffix of files being downloaded.
+const partialFileSuffix = ".part"
+
+// assembleFile assembles the chunked file into a partial file and moves it
+// to path once the content is verified.
+func assembleFile(ctx context.Context, src content.Fetcher, f chunk.File, path string, onChunk func(ocispec.Descriptor, bool) error) (err error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	fp, err := os.OpenFile(path+partialFileSuffix, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := fp.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(fp.Name())
+			return
+		}
+		err = os.Rename(fp.Name(), path)
+	}()
+	return chunk.Assemble(ctx, src, f, fp, onChunk)
+}
+
+// resolveOutputPath resolves the path of a pulled file named name under the
+// output directory.
+func resolveOutputPath(outputDir string, name string, allowPathTraversal bool) (string, error) {
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(outputDir, path)
+	}
+	if allowPathTraversal {
+		return path, nil
+	}
+	base, err := filepath.Abs(outputDir)
+	if err != nil {
+		return "", err
+	}
+	target, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(base, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%q: %w", name, file.ErrPathTraversalDisallowed)
+	}
+	return path, nil
 }
 
 func notifyOnce(notified *sync.Map, s ocispec.Descriptor, notify func(ocispec.Descriptor) error) error {
@@ -16,12 +16,33 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
 	"testing"
+	"text/template"
 
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
-	"oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/chunk"
+	orasfile "oras.land/oras/internal/file"
+	"oras.land/oras/internal/pathfilter"
 )
 
 func Test_runPull_errType(t *testing.T) {
@@ -36,8 +57,413 @@ func Test_runPull_errType(t *testing.T) {
 		},
 	}
 	got := runPull(cmd, opts).Error()
-	want := errors.UnsupportedFormatTypeError(opts.Format.Type).Error()
+	want := oerrors.UnsupportedFormatTypeError(opts.Format.Type).Error()
 	if got != want {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func Test_resolveOutputPath(t *testing.T) {
+	outputDir := t.TempDir()
+	tests := []struct {
+		name               string
+		fileName           string
+		allowPathTraversal bool
+		want               string
+		wantErr            error
+	}{
+		{"relative path", "foo/bar.txt", false, filepath.Join(outputDir, "foo", "bar.txt"), nil},
+		{"path traversal disallowed", "../bar.txt", false, "", file.ErrPathTraversalDisallowed},
+		{"path traversal allowed", "../bar.txt", true, filepath.Join(outputDir, "..", "bar.txt"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveOutputPath(outputDir, tt.fileName, tt.allowPathTraversal)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolveOutputPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveOutputPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pullOptions_filterFiles(t *testing.T) {
+	filter, err := pathfilter.New([]string{"bin/linux-*"}, []string{"*.pdb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	po := &pullOptions{
+		filter:     filter,
+		mediaTypes: []string{"application/x-bin"},
+	}
+	newFile := func(name string, mediaType string) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			MediaType:   mediaType,
+			Annotations: map[string]string{ocispec.AnnotationTitle: name},
+		}
+	}
+	manifest := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest}
+	chunkNode := ocispec.Descriptor{
+		MediaType:   "application/x-bin",
+		Annotations: map[string]string{chunk.AnnotationTitle: "bin/windows.exe"},
+	}
+	nodes := []ocispec.Descriptor{
+		newFile("bin/linux-amd64", "application/x-bin"),
+		newFile("bin/linux-amd64.pdb", "application/x-bin"),
+		newFile("bin/linux-arm64", "text/plain"),
+		manifest,
+		chunkNode,
+	}
+	var skipped []string
+	got, err := po.filterFiles(nodes, func(name string, _ ocispec.Descriptor) error {
+		skipped = append(skipped, name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("filterFiles() error =", err)
+	}
+	if want := []ocispec.Descriptor{nodes[0], manifest}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filterFiles() = %v, want %v", got, want)
+	}
+	if want := []string{"bin/linux-amd64.pdb", "bin/linux-arm64", "bin/windows.exe"}; !reflect.DeepEqual(skipped, want) {
+		t.Errorf("skipped files = %v, want %v", skipped, want)
+	}
+}
+
+func Test_fileMatches(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hello.txt")
+	blob := []byte("hello")
+	if err := os.WriteFile(path, blob, 0600); err != nil {
+		t.Fatal(err)
+	}
+	desc := ocispec.Descriptor{
+		MediaType: "text/plain",
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	tests := []struct {
+		name string
+		path string
+		desc ocispec.Descriptor
+		want bool
+	}{
+		{"matched", path, desc, true},
+		{"missing file", filepath.Join(dir, "missing"), desc, false},
+		{"directory", dir, desc, false},
+		{"size mismatched", path, ocispec.Descriptor{Digest: desc.Digest, Size: 1}, false},
+		{"digest mismatched", path, ocispec.Descriptor{Digest: digest.FromBytes([]byte("world")), Size: desc.Size}, false},
+		{"invalid digest", path, ocispec.Descriptor{Digest: "sha256:invalid", Size: desc.Size}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fileMatches(tt.path, tt.desc)
+			if err != nil {
+				t.Fatal("fileMatches() error =", err)
+			}
+			if got != tt.want {
+				t.Errorf("fileMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pullOptions_platformOutputDir(t *testing.T) {
+	outputDir := t.TempDir()
+	tests := []struct {
+		name         string
+		platformPath string
+		platform     ocispec.Platform
+		want         string
+		wantErr      bool
+	}{
+		{"default", "{{.OS}}_{{.Architecture}}{{with .Variant}}_{{.}}{{end}}", ocispec.Platform{OS: "linux", Architecture: "amd64"}, filepath.Join(outputDir, "linux_amd64"), false},
+		{"default with variant", "{{.OS}}_{{.Architecture}}{{with .Variant}}_{{.}}{{end}}", ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, filepath.Join(outputDir, "linux_arm_v7"), false},
+		{"nested", "{{.OS}}/{{.Architecture}}", ocispec.Platform{OS: "linux", Architecture: "amd64"}, filepath.Join(outputDir, "linux", "amd64"), false},
+		{"empty", "{{.Variant}}", ocispec.Platform{OS: "linux", Architecture: "amd64"}, "", true},
+		{"path traversal", "../{{.OS}}", ocispec.Platform{OS: "linux", Architecture: "amd64"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			po := &pullOptions{
+				Output:               outputDir,
+				platformPathTemplate: template.Must(template.New("platform path").Parse(tt.platformPath)),
+			}
+			got, err := po.platformOutputDir(&tt.platform)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("platformOutputDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("platformOutputDir() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pullOptions_storeDir(t *testing.T) {
+	outputDir := t.TempDir()
+	stagingDir := filepath.Join(filepath.Dir(outputDir), ".out.staging-1")
+	tests := []struct {
+		name        string
+		stagingRoot string
+		path        string
+		want        string
+	}{
+		{"not atomic", "", filepath.Join(outputDir, "a", "b.txt"), filepath.Join(outputDir, "a", "b.txt")},
+		{"root", stagingDir, outputDir, stagingDir},
+		{"file", stagingDir, filepath.Join(outputDir, "a", "b.txt"), filepath.Join(stagingDir, "a", "b.txt")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			po := &pullOptions{
+				Output:      outputDir,
+				outputRoot:  outputDir,
+				stagingRoot: tt.stagingRoot,
+			}
+			if got := po.storeDir(tt.path); got != tt.want {
+				t.Errorf("storeDir() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_latestReferrer(t *testing.T) {
+	newReferrer := func(content string, created string) ocispec.Descriptor {
+		desc := ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromString(content),
+		}
+		if created != "" {
+			desc.Annotations = map[string]string{ocispec.AnnotationCreated: created}
+		}
+		return desc
+	}
+	noCreated := newReferrer("no created", "")
+	invalid := newReferrer("invalid", "yesterday")
+	older := newReferrer("older", "2024-01-01T00:00:00Z")
+	newer := newReferrer("newer", "2024-06-01T08:00:00+08:00")
+	tests := []struct {
+		name      string
+		referrers []ocispec.Descriptor
+		want      ocispec.Descriptor
+	}{
+		{"single", []ocispec.Descriptor{older}, older},
+		{"newest first", []ocispec.Descriptor{newer, older}, newer},
+		{"newest last", []ocispec.Descriptor{noCreated, older, invalid, newer}, newer},
+		{"no valid time", []ocispec.Descriptor{noCreated, invalid}, noCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := latestReferrer(tt.referrers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("latestReferrer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pullAllPlatforms_attestationManifests(t *testing.T) {
+	ctx := context.Background()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	push := func(mediaType string, blob []byte, annotations map[string]string, platform *ocispec.Platform) ocispec.Descriptor {
+		t.Helper()
+		desc := content.NewDescriptorFromBytes(mediaType, blob)
+		desc.Annotations = annotations
+		if err := store.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		desc.Platform = platform
+		return desc
+	}
+	config := push(ocispec.MediaTypeImageConfig, []byte("{}"), nil, nil)
+	pushManifest := func(file string, platform *ocispec.Platform, annotations map[string]string) ocispec.Descriptor {
+		t.Helper()
+		layer := push("application/vnd.example", []byte(file), map[string]string{ocispec.AnnotationTitle: file}, nil)
+		manifest := ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    config,
+			Layers:    []ocispec.Descriptor{layer},
+		}
+		manifestJSON, err := json.Marshal(manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		desc := push(ocispec.MediaTypeImageManifest, manifestJSON, nil, platform)
+		desc.Annotations = annotations
+		return desc
+	}
+	amd64 := pushManifest("amd64.txt", &ocispec.Platform{OS: "linux", Architecture: "amd64"}, nil)
+	arm64 := pushManifest("arm64.txt", &ocispec.Platform{OS: "linux", Architecture: "arm64"}, nil)
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{
+			amd64,
+			arm64,
+			pushManifest("amd64.att", &ocispec.Platform{OS: "unknown", Architecture: "unknown"}, map[string]string{
+				"vnd.docker.reference.digest": amd64.Digest.String(),
+				"vnd.docker.reference.type":   "attestation-manifest",
+			}),
+			pushManifest("arm64.att", &ocispec.Platform{OS: "unknown", Architecture: "unknown"}, map[string]string{
+				"vnd.docker.reference.digest": arm64.Digest.String(),
+				"vnd.docker.reference.type":   "attestation-manifest",
+			}),
+		},
+	}
+	indexJSON, err := json.Marshal(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := push(ocispec.MediaTypeImageIndex, indexJSON, nil, nil)
+	if err := store.Tag(ctx, root, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	po := &pullOptions{
+		Output:               t.TempDir(),
+		concurrency:          1,
+		platformPathTemplate: template.Must(template.New("platform path").Parse("{{.OS}}_{{.Architecture}}{{with .Variant}}_{{.}}{{end}}")),
+	}
+	po.Reference = "v1"
+	po.Printer = output.NewPrinter(&out, &out)
+	statusHandler, metadataHandler, err := display.NewPullHandler(po.Printer, option.Format{Type: option.FormatTypeText.Name}, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := pullAllPlatforms(ctx, store, oras.DefaultCopyOptions, metadataHandler, statusHandler, po)
+	if err != nil {
+		t.Fatal("pullAllPlatforms() error =", err)
+	}
+	if got.Digest != root.Digest {
+		t.Errorf("pullAllPlatforms() = %v, want %v", got.Digest, root.Digest)
+	}
+	for _, name := range []string{filepath.Join("linux_amd64", "amd64.txt"), filepath.Join("linux_arm64", "arm64.txt")} {
+		if _, err := os.Stat(filepath.Join(po.Output, name)); err != nil {
+			t.Errorf("file %s not pulled: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(po.Output, "unknown_unknown")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("attestation manifests pulled: %v", err)
+	}
+}
+
+func Test_checkReplaceDir(t *testing.T) {
+	root := t.TempDir()
+	wd := filepath.Join(root, "work")
+	if err := os.Mkdir(wd, 0777); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(wd)
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"output not specified", nil, true},
+		{"working directory", []string{"-o", "."}, true},
+		{"parent of working directory", []string{"-o", root}, true},
+		{"subdirectory", []string{"-o", "out"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := pullCmd()
+			if err := cmd.ParseFlags(append([]string{"--replace-dir"}, tt.args...)); err != nil {
+				t.Fatal("ParseFlags() error =", err)
+			}
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := checkReplaceDir(cmd, output); (err != nil) != tt.wantErr {
+				t.Errorf("checkReplaceDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := os.Stat(wd); err != nil {
+		t.Errorf("working directory removed: %v", err)
+	}
+}
+
+func Test_modeTarget_Push(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not supported on Windows")
+	}
+	ctx := context.Background()
+	srcDir := t.TempDir()
+	app := filepath.Join(srcDir, "app")
+	if err := os.WriteFile(app, []byte("app"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(app, 0777); err != nil {
+		t.Fatal(err)
+	}
+	src, err := file.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	dirDesc, err := src.Add(ctx, "dir", "", srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := content.FetchAll(ctx, src, dirDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the mode of a file extracted without the permission bits preserved is
+	// subject to the umask
+	probe := filepath.Join(t.TempDir(), "probe")
+	if err := os.WriteFile(probe, nil, 0777); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(probe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaultMode := fi.Mode().Perm()
+
+	tests := []struct {
+		name                string
+		preservePermissions bool
+		annotations         map[string]string
+		want                os.FileMode
+	}{
+		{"default", false, nil, defaultMode},
+		{"recorded on push", false, map[string]string{orasfile.AnnotationMode: "0755"}, 0777},
+		{"requested", true, nil, 0777},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			dst, err := file.New(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer dst.Close()
+			dst.PreservePermissions = tt.preservePermissions
+			desc := dirDesc
+			desc.Annotations = maps.Clone(dirDesc.Annotations)
+			maps.Copy(desc.Annotations, tt.annotations)
+			target := newModeTarget(dst, dir)
+			if err := target.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+				t.Fatal("Push() error =", err)
+			}
+			if dst.PreservePermissions != tt.preservePermissions {
+				t.Errorf("PreservePermissions = %v, want unchanged %v", dst.PreservePermissions, tt.preservePermissions)
+			}
+			fi, err := os.Stat(filepath.Join(dir, "dir", "app"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fi.Mode().Perm(); got != tt.want {
+				t.Errorf("mode = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,8 +17,10 @@ package root
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -29,18 +31,24 @@ import (
 	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/registry/remote/auth"
 	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/artifactspec"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
 	"oras.land/oras/cmd/oras/internal/display/status"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/fileref"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/chunk"
 	"oras.land/oras/internal/contentutil"
+	orasfile "oras.land/oras/internal/file"
+	"oras.land/oras/internal/graph"
 	"oras.land/oras/internal/listener"
 	"oras.land/oras/internal/registryutil"
 )
 
 type pushOptions struct {
+	option.Cache
 	option.Common
 	option.Packer
 	option.ArtifactPlatform
@@ -49,10 +57,18 @@ type pushOptions struct {
 	option.Format
 	option.Terminal
 
-	extraRefs         []string
-	manifestConfigRef string
-	artifactType      string
-	concurrency       int
+	extraRefs           []string
+	manifestConfigRef   string
+	artifactType        string
+	subject             string
+	concurrency         int
+	stdinName           string
+	stdinMediaType      string
+	chunkSizeFlag       string
+	chunkSize           int64
+	specPath            string
+	spec                *artifactspec.Spec
+	preservePermissions bool
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -79,6 +95,21 @@ Example - Push multiple files with different media types:
 Example - Push file with colon in name "hi:txt" with the default media type:
   oras push localhost:5000/hello:v1 hi:txt:
 
+Example - Push files with media types detected from their content and extensions:
+  oras push --detect-media-type localhost:5000/hello:v1 sbom.spdx.json chart.tgz
+
+Example - Push files with media types detected using a custom extension mapping file "mapping.json":
+  oras push --detect-media-type --media-type-mapping mapping.json localhost:5000/hello:v1 README.md
+
+Example - Push the files matching "dist/*" except "*.pdb" files:
+  oras push --exclude "*.pdb" localhost:5000/hello:v1 "dist/*"
+
+Example - Push directory "site" as a directory layer without the files listed in "site/.orasignore":
+  oras push localhost:5000/hello:v1 site
+
+Example - Push only the linux binaries in directory "bin" as a directory layer:
+  oras push --include "bin/linux-*" localhost:5000/hello:v1 bin
+
 Example - Push file "hi.txt" with artifact type "application/vnd.example+type":
   oras push --artifact-type application/vnd.example+type localhost:5000/hello:v1 hi.txt
 
@@ -109,6 +140,30 @@ Example - Push repository with manifest annotation file:
 Example - [Experimental] Push artifact to repository with platform:
   oras push --artifact-platform linux/arm/v5 localhost:5000/hello:v1
 
+Example - Push content read from stdin as file "report.json" with the media type "application/json":
+  generate-report | oras push --name report.json --stdin-media-type application/json localhost:5000/hello:v1 -
+
+Example - Push content read from stdin with the file reference "-:application/json" given after "--":
+  generate-report | oras push --name report.json localhost:5000/hello:v1 -- -:application/json
+
+Example - Push file "model.bin" split into layers of at most 5 GiB each:
+  oras push --chunk-size 5G localhost:5000/hello:v1 model.bin
+
+Example - Push file "hi.txt" as a referrer of the manifest tagged "v1" and tag it as "v1-sbom":
+  oras push --subject v1 --artifact-type application/vnd.example.sbom localhost:5000/hello:v1-sbom hi.txt
+
+Example - Push file "hi.txt" as a referrer using a specific method for the Referrers API:
+  oras push --subject v1 --artifact-type doc/example --distribution-spec v1.1-referrers-tag localhost:5000/hello hi.txt
+
+Example - Push the artifact described by the spec file "artifact.yaml":
+  oras push --spec artifact.yaml localhost:5000/hello:v1
+
+Example - Push the executable "app" keeping its permission bits and modification time:
+  oras push --preserve-permissions localhost:5000/hello:v1 app
+
+Example - Push file "hi.txt" and store the pushed content in the local cache:
+  oras push --cache ~/.oras/cache --populate-cache localhost:5000/hello:v1 hi.txt
+
 Example - Push file "hi.txt" with multiple tags:
   oras push localhost:5000/hello:tag1,tag2,tag3 hi.txt
 
@@ -127,24 +182,53 @@ Example - Push file "hi.txt" into an OCI image layout folder 'layout-dir' with t
 			opts.RawReference = refs[0]
 			opts.extraRefs = refs[1:]
 			opts.FileRefs = args[1:]
+			if err := opts.loadSpec(cmd); err != nil {
+				return err
+			}
+			if err := opts.checkStdinFile(cmd); err != nil {
+				return err
+			}
 			if err := option.Parse(cmd, &opts); err != nil {
 				return err
 			}
+			if err := opts.applySpec(); err != nil {
+				return err
+			}
 			opts.DisableTTY(opts.Debug, false)
-			if opts.manifestConfigRef != "" && opts.artifactType == "" {
-				if !cmd.Flags().Changed("image-spec") {
+			if opts.hasConfig() && opts.artifactType == "" {
+				if !cmd.Flags().Changed("image-spec") && opts.subject == "" {
 					// switch to v1.0 manifest since artifact type is suggested
 					// by OCI v1.1 artifact guidance but is not presented
 					// see https://github.com/opencontainers/image-spec/blob/e7f7c0ca69b21688c3cea7c87a04e4503e6099e2/manifest.md?plain=1#L170
 					opts.Flag = option.ImageSpecV1_0
 					opts.PackVersion = oras.PackManifestVersion1_0
 				} else if opts.Flag == option.ImageSpecV1_1 {
+					recommendation := "set an artifact type via `--artifact-type` or consider image spec v1.0"
+					if opts.subject != "" {
+						recommendation = "set an artifact type via `--artifact-type` since a subject is specified"
+					}
 					return &oerrors.Error{
 						Err:            errors.New(`missing artifact type for OCI image-spec v1.1 artifacts`),
-						Recommendation: "set an artifact type via `--artifact-type` or consider image spec v1.0",
+						Recommendation: recommendation,
 					}
 				}
 			}
+			if opts.chunkSizeFlag != "" {
+				chunkSize, err := humanize.ParseBytes(opts.chunkSizeFlag)
+				if err != nil {
+					return fmt.Errorf("invalid value for --chunk-size: %w", err)
+				}
+				if chunkSize <= 0 {
+					return errors.New("--chunk-size must be positive")
+				}
+				opts.chunkSize = chunkSize
+			}
+			if opts.subject != "" && opts.PackVersion == oras.PackManifestVersion1_0 {
+				return &oerrors.Error{
+					Err:            errors.New("subject cannot be set for OCI image-spec v1.0 manifests"),
+					Recommendation: "consider using image spec v1.1 or remove --subject",
+				}
+			}
 			configAndPlatform := []string{"config", "artifact-platform"}
 			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), configAndPlatform...); err != nil {
 				return err
@@ -152,11 +236,11 @@ Example - Push file "hi.txt" into an OCI image layout folder 'layout-dir' with t
 
 			switch opts.PackVersion {
 			case oras.PackManifestVersion1_0:
-				if opts.manifestConfigRef != "" && opts.artifactType != "" {
+				if opts.hasConfig() && opts.artifactType != "" {
 					return errors.New("--artifact-type and --config cannot both be provided for 1.0 OCI image")
 				}
 			case oras.PackManifestVersion1_1:
-				if opts.manifestConfigRef == "" && opts.artifactType == "" {
+				if !opts.hasConfig() && opts.artifactType == "" {
 					opts.artifactType = oras.MediaTypeUnknownArtifact
 				}
 			}
@@ -169,14 +253,201 @@ Example - Push file "hi.txt" into an OCI image layout folder 'layout-dir' with t
 	}
 	cmd.Flags().StringVarP(&opts.manifestConfigRef, "config", "", "", "`path` of image config file")
 	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "artifact type")
+	cmd.Flags().StringVarP(&opts.subject, "subject", "", "", "tag or digest of the subject `reference` in the destination repository, making the pushed artifact a referrer of it")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	cmd.Flags().StringVarP(&opts.stdinName, "name", "", "", "file `name` of the content read from stdin via the file path `-`")
+	cmd.Flags().StringVarP(&opts.stdinMediaType, "stdin-media-type", "", "", "media `type` of the content read from stdin via the file path `-`")
+	cmd.Flags().StringVarP(&opts.specPath, "spec", "", "", "`path` of the YAML or JSON file describing the artifact to push")
+	cmd.Flags().StringVarP(&opts.chunkSizeFlag, "chunk-size", "", "", "split files larger than the `size` (e.g. 5G) into multiple layers")
+	cmd.Flags().BoolVarP(&opts.preservePermissions, "preserve-permissions", "", false, "record the permission bits and modification times of the files as annotations, which are applied on pull")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
+	opts.EnableDistributionSpecFlag()
+	opts.EnablePopulateFlag()
 	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
 }
 
+// loadSpec loads the artifact spec file and merges it into the command line
+// flags and arguments before they are parsed.
+func (opts *pushOptions) loadSpec(cmd *cobra.Command) error {
+	if opts.specPath == "" {
+		return nil
+	}
+	spec, err := artifactspec.Load(opts.specPath)
+	if err != nil {
+		return err
+	}
+	conflicts := map[string]bool{
+		"artifact-type":     spec.ArtifactType != "",
+		"config":            spec.Config != nil,
+		"artifact-platform": spec.Platform != nil,
+		"subject":           spec.Subject != "",
+	}
+	for flag, inSpec := range conflicts {
+		if inSpec && cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--%s cannot be used since it is specified in the spec file %s", flag, opts.specPath)
+		}
+	}
+	if spec.Config != nil && cmd.Flags().Changed("artifact-platform") {
+		return errors.New("the config in the spec file cannot be used with --artifact-platform")
+	}
+	if spec.Platform != nil && cmd.Flags().Changed("config") {
+		return errors.New("the platform in the spec file cannot be used with --config")
+	}
+
+	if spec.ArtifactType != "" {
+		opts.artifactType = spec.ArtifactType
+	}
+	if spec.Subject != "" {
+		opts.subject = spec.Subject
+	}
+	opts.spec = spec
+	return nil
+}
+
+// hasConfig returns true if the config file is specified either via
+// `--config` or in the spec file.
+func (opts *pushOptions) hasConfig() bool {
+	return opts.manifestConfigRef != "" || opts.spec != nil && opts.spec.Config != nil
+}
+
+// configFile returns the path and the media type of the config file specified
+// either via `--config` or in the spec file. The returned path is empty if no
+// config file is specified.
+func (opts *pushOptions) configFile() (string, string, error) {
+	if opts.spec != nil && opts.spec.Config != nil {
+		mediaType := opts.spec.Config.MediaType
+		if mediaType == "" {
+			mediaType = oras.MediaTypeUnknownConfig
+		}
+		return opts.spec.Config.Path, mediaType, nil
+	}
+	if opts.manifestConfigRef == "" {
+		return "", "", nil
+	}
+	return fileref.Parse(opts.manifestConfigRef, oras.MediaTypeUnknownConfig)
+}
+
+// fileSpecs returns the files to be pushed, with the files in the spec file
+// followed by the files specified via arguments.
+func (opts *pushOptions) fileSpecs() ([]fileSpec, error) {
+	files, err := parseFileRefs(opts.FileRefs)
+	if err != nil {
+		return nil, err
+	}
+	if opts.spec == nil {
+		return files, nil
+	}
+	specFiles := make([]fileSpec, 0, len(opts.spec.Files)+len(files))
+	for _, f := range opts.spec.Files {
+		specFiles = append(specFiles, fileSpec{
+			path:      f.Path,
+			name:      f.Name,
+			mediaType: f.MediaType,
+		})
+	}
+	return append(specFiles, files...), nil
+}
+
+// applySpec applies the settings in the artifact spec file which depend on the
+// parsed flags.
+func (opts *pushOptions) applySpec() error {
+	spec := opts.spec
+	if spec == nil {
+		return nil
+	}
+	if spec.Platform != nil {
+		opts.Platform.Platform = spec.Platform
+	}
+	if opts.Annotations == nil {
+		opts.Annotations = make(map[string]map[string]string)
+	}
+	merge := func(key string, annotations map[string]string) error {
+		if len(annotations) == 0 {
+			return nil
+		}
+		if opts.Annotations[key] == nil {
+			opts.Annotations[key] = make(map[string]string, len(annotations))
+		}
+		for k, v := range annotations {
+			if _, ok := opts.Annotations[key][k]; ok {
+				return fmt.Errorf("annotation %q in the spec file %s is also specified via flags", k, opts.specPath)
+			}
+			opts.Annotations[key][k] = v
+		}
+		return nil
+	}
+	if err := merge(option.AnnotationManifest, spec.Annotations); err != nil {
+		return err
+	}
+	if spec.Config != nil {
+		if err := merge(option.AnnotationConfig, spec.Config.Annotations); err != nil {
+			return err
+		}
+	}
+	for _, f := range spec.Files {
+		if err := merge(f.Path, f.Annotations); err != nil {
+			return err
+		}
+	}
+	tags := spec.Tags
+	if len(tags) > 0 && opts.Reference == "" {
+		opts.Reference = tags[0]
+		if opts.Target.Type == option.TargetTypeOCILayout && !opts.IsOCILayout {
+			// the raw reference is the tag when --oci-layout-path is used
+			opts.RawReference = tags[0]
+		} else {
+			opts.RawReference += ":" + tags[0]
+		}
+		tags = tags[1:]
+	}
+	opts.extraRefs = append(opts.extraRefs, tags...)
+	return nil
+}
+
+// checkStdinFile validates the usage of reading file content from stdin, and
+// applies the media type specified by `--stdin-media-type` to the file
+// reference `-`.
+func (opts *pushOptions) checkStdinFile(cmd *cobra.Command) error {
+	var count int
+	for i, fileRef := range opts.FileRefs {
+		path, mediaType, err := fileref.Parse(fileRef, "")
+		if err != nil {
+			return err
+		}
+		if path != stdinFileName {
+			continue
+		}
+		count++
+		if opts.stdinMediaType != "" {
+			if mediaType != "" {
+				return fmt.Errorf("`--stdin-media-type` cannot be used with the media type in the file reference %q", fileRef)
+			}
+			opts.FileRefs[i] = stdinFileName + ":" + opts.stdinMediaType
+		}
+	}
+	switch {
+	case count == 0:
+		if opts.stdinName != "" {
+			return errors.New("`--name` can only be used when reading file content from stdin via `-`")
+		}
+		if opts.stdinMediaType != "" {
+			return errors.New("`--stdin-media-type` can only be used when reading file content from stdin via `-`")
+		}
+		return nil
+	case count > 1:
+		return errors.New("`-` can only be specified once since stdin can only be read once")
+	case opts.stdinName == "":
+		return &oerrors.Error{
+			Err:            errors.New("missing the file name of the content read from stdin"),
+			Recommendation: "provide the file name via `--name`",
+		}
+	}
+	return option.CheckStdinConflict(cmd.Flags())
+}
+
 func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 
@@ -190,11 +461,11 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 		return err
 	}
 	defer func() { _ = store.Close() }()
-	if opts.manifestConfigRef != "" {
-		path, cfgMediaType, err := fileref.Parse(opts.manifestConfigRef, oras.MediaTypeUnknownConfig)
-		if err != nil {
-			return err
-		}
+	path, cfgMediaType, err := opts.configFile()
+	if err != nil {
+		return err
+	}
+	if path != "" {
 		desc, err := addFile(ctx, store, option.AnnotationConfig, cfgMediaType, path)
 		if err != nil {
 			return err
@@ -220,13 +491,32 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 		desc.Annotations = packOpts.ConfigAnnotations
 		packOpts.ConfigDescriptor = &desc
 	}
+	loadOpts := &loadOptions{
+		stdinName:           opts.stdinName,
+		spool:               orasfile.NewSpool(),
+		chunkSize:           opts.chunkSize,
+		chunks:              chunk.NewStore(),
+		detector:            opts.MediaTypeDetector,
+		filter:              opts.FileFilter,
+		preservePermissions: opts.preservePermissions,
+	}
+	defer func() { _ = loadOpts.close() }()
 	memoryStore := memory.New()
-	union := contentutil.MultiReadOnlyTarget(memoryStore, store)
+	union := contentutil.MultiReadOnlyTarget(memoryStore, store, loadOpts.spool, loadOpts.chunks)
 	statusHandler, metadataHandler, err := display.NewPushHandler(opts.Printer, opts.Format, opts.TTY, union)
 	if err != nil {
 		return err
 	}
-	descs, err := loadFiles(ctx, store, opts.Annotations, opts.FileRefs, statusHandler)
+	for _, name := range opts.ExcludedFiles {
+		if err := statusHandler.OnFileExcluded(name); err != nil {
+			return err
+		}
+	}
+	files, err := opts.fileSpecs()
+	if err != nil {
+		return err
+	}
+	descs, err := loadFiles(ctx, store, loadOpts, opts.Annotations, files, statusHandler)
 	if err != nil {
 		return err
 	}
@@ -247,6 +537,13 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	if err != nil {
 		return err
 	}
+	if opts.subject != "" {
+		subject, err := oras.Resolve(ctx, originalDst, opts.subject, oras.DefaultResolveOptions)
+		if err != nil {
+			return fmt.Errorf("failed to resolve subject %s: %w", opts.subject, err)
+		}
+		packOpts.Subject = &subject
+	}
 	dst, stopTrack, err := statusHandler.TrackTarget(originalDst)
 	if err != nil {
 		return err
@@ -257,6 +554,22 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	copyOptions.PreCopy = statusHandler.PreCopy
 	copyOptions.PostCopy = statusHandler.PostCopy
 	copyWithScopeHint := func(root ocispec.Descriptor) error {
+		if packOpts.Subject != nil {
+			copyOptions.FindSuccessors = func(ctx context.Context, fetcher content.Fetcher, node ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+				if content.Equal(node, root) {
+					// skip duplicated Resolve on subject
+					successors, _, config, err := graph.Successors(ctx, fetcher, node)
+					if err != nil {
+						return nil, err
+					}
+					if config != nil {
+						successors = append(successors, *config)
+					}
+					return successors, nil
+				}
+				return content.Successors(ctx, fetcher, node)
+			}
+		}
 		// add both pull and push scope hints for dst repository
 		// to save potential push-scope token requests during copy
 		ctx = registryutil.WithScopeHint(ctx, originalDst, auth.ActionPull, auth.ActionPush)
@@ -274,6 +587,9 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	if err != nil {
 		return err
 	}
+	if err := opts.Populate(ctx, union, root); err != nil {
+		logger.Warnf("failed to populate the cache: %v", err)
+	}
 	err = metadataHandler.OnCopied(&opts.Target, root)
 	if err != nil {
 		return err
@@ -17,11 +17,16 @@ package root
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
 	"oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/pathfilter"
 )
 
 func Test_runPush_errType(t *testing.T) {
@@ -41,3 +46,208 @@ func Test_runPush_errType(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func Test_pushOptions_checkStdinFile(t *testing.T) {
+	tests := []struct {
+		name           string
+		fileRefs       []string
+		stdinName      string
+		stdinMediaType string
+		want           []string
+		wantErr        bool
+	}{
+		{"no stdin", []string{"foo.txt"}, "", "", []string{"foo.txt"}, false},
+		{"stdin with name", []string{"foo.txt", "-:application/json"}, "report.json", "", []string{"foo.txt", "-:application/json"}, false},
+		{"stdin with media type flag", []string{"-", "foo.txt"}, "report.json", "application/json", []string{"-:application/json", "foo.txt"}, false},
+		{"stdin without media type", []string{"-:"}, "report.json", "", []string{"-:"}, false},
+		{"stdin without name", []string{"-"}, "", "", nil, true},
+		{"name without stdin", []string{"foo.txt"}, "report.json", "", nil, true},
+		{"media type flag without stdin", []string{"foo.txt"}, "", "application/json", nil, true},
+		{"media type flag with media type", []string{"-:text/plain"}, "report.json", "application/json", nil, true},
+		{"multiple stdin", []string{"-", "-:application/json"}, "report.json", "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := pushCmd()
+			opts := &pushOptions{
+				stdinName:      tt.stdinName,
+				stdinMediaType: tt.stdinMediaType,
+			}
+			opts.FileRefs = tt.fileRefs
+			err := opts.checkStdinFile(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkStdinFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(opts.FileRefs, tt.want) {
+				t.Errorf("checkStdinFile() file refs = %v, want %v", opts.FileRefs, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pushOptions_checkStdinFile_passwordStdin(t *testing.T) {
+	cmd := pushCmd()
+	if err := cmd.Flags().Set("password-stdin", "true"); err != nil {
+		t.Fatal(err)
+	}
+	opts := &pushOptions{
+		stdinName: "report.json",
+	}
+	opts.FileRefs = []string{"-"}
+	if err := opts.checkStdinFile(cmd); err == nil {
+		t.Fatal("checkStdinFile() error = nil, want conflict error")
+	}
+}
+
+func Test_pushCmd_stdinFileRefs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{"stdin between files", []string{"localhost:5000/hello:v1", "foo.txt", "-", "--name", "report.json", "bar.txt"}, []string{"localhost:5000/hello:v1", "foo.txt", "-", "bar.txt"}, false},
+		{"stdin after dash", []string{"localhost:5000/hello:v1", "--", "-:application/json"}, []string{"localhost:5000/hello:v1", "-:application/json"}, false},
+		{"bare stdin after dash", []string{"localhost:5000/hello:v1", "--", "-:", "foo.txt"}, []string{"localhost:5000/hello:v1", "-:", "foo.txt"}, false},
+		// the file reference is never taken as a flag value
+		{"bare stdin", []string{"localhost:5000/hello:v1", "-:", "foo.txt"}, nil, true},
+		{"stdin with media type", []string{"localhost:5000/hello:v1", "-:application/json"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := pushCmd()
+			err := cmd.ParseFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(cmd.Flags().Args(), tt.want) {
+				t.Errorf("Args() = %v, want %v", cmd.Flags().Args(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_pushOptions_loadSpec(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"hi:there.txt", "hi:", "config.json"} {
+		if err := os.WriteFile(name, []byte("hi"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	spec := "artifactType: application/vnd.example\ntags: [v1, v2]\nannotations:\n  foo: bar\nconfig:\n  path: config.json\nfiles:\n  - path: \"hi:there.txt\"\n  - path: \"hi:\"\n    mediaType: text/plain\n"
+	if err := os.WriteFile("artifact.yaml", []byte(spec), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := pushCmd()
+	opts := &pushOptions{
+		specPath: "artifact.yaml",
+	}
+	opts.FileRefs = []string{"bye.txt"}
+	opts.RawReference = "localhost:5000/hello"
+	opts.Target.Type = option.TargetTypeRemote
+	if err := opts.loadSpec(cmd); err != nil {
+		t.Fatal("loadSpec() error =", err)
+	}
+	if err := opts.applySpec(); err != nil {
+		t.Fatal("applySpec() error =", err)
+	}
+	files, err := opts.fileSpecs()
+	if err != nil {
+		t.Fatal("fileSpecs() error =", err)
+	}
+	wantFiles := []fileSpec{
+		{path: "hi:there.txt", name: "hi:there.txt"},
+		{path: "hi:", name: "hi:", mediaType: "text/plain"},
+		{path: "bye.txt"},
+	}
+	if !reflect.DeepEqual(files, wantFiles) {
+		t.Errorf("fileSpecs() = %v, want %v", files, wantFiles)
+	}
+	if !opts.hasConfig() {
+		t.Error("hasConfig() = false, want true")
+	}
+	if path, mediaType, err := opts.configFile(); err != nil || path != "config.json" || mediaType != oras.MediaTypeUnknownConfig {
+		t.Errorf("configFile() = %v, %v, %v, want config.json, %v", path, mediaType, err, oras.MediaTypeUnknownConfig)
+	}
+	if opts.artifactType != "application/vnd.example" {
+		t.Errorf("artifactType = %v, want %v", opts.artifactType, "application/vnd.example")
+	}
+	if opts.Reference != "v1" || opts.RawReference != "localhost:5000/hello:v1" {
+		t.Errorf("Reference = %v, RawReference = %v, want v1", opts.Reference, opts.RawReference)
+	}
+	if want := []string{"v2"}; !reflect.DeepEqual(opts.extraRefs, want) {
+		t.Errorf("extraRefs = %v, want %v", opts.extraRefs, want)
+	}
+	if got := opts.Annotations[option.AnnotationManifest]["foo"]; got != "bar" {
+		t.Errorf("manifest annotation foo = %v, want bar", got)
+	}
+
+	// conflicts with flags
+	cmd = pushCmd()
+	if err := cmd.Flags().Set("artifact-type", "foo"); err != nil {
+		t.Fatal(err)
+	}
+	opts = &pushOptions{
+		specPath: "artifact.yaml",
+	}
+	if err := opts.loadSpec(cmd); err == nil {
+		t.Error("loadSpec() error = nil, want conflict error")
+	}
+}
+
+func Test_loadOptions_directoryFilter(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"site/index.html", "site/draft.md", "site/assets/app.js", "bin/app", "bin/app.pdb"} {
+		if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(name), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join("site", pathfilter.IgnoreFileName), []byte("*.md\n/assets/\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// nothing excluded
+	opts := &loadOptions{}
+	if excluded, err := opts.directoryFilter("bin"); err != nil || excluded != nil {
+		t.Fatalf("directoryFilter(bin) error = %v, want no filter", err)
+	}
+	if excluded, err := opts.directoryFilter(filepath.Join("bin", "app")); err != nil || excluded != nil {
+		t.Fatalf("directoryFilter(bin/app) error = %v, want no filter", err)
+	}
+
+	// ignore file relative to the directory
+	excluded, err := opts.directoryFilter("site")
+	if err != nil || excluded == nil {
+		t.Fatalf("directoryFilter(site) error = %v, want filter", err)
+	}
+	tests := []struct {
+		name  string
+		isDir bool
+		want  bool
+	}{
+		{"index.html", false, false},
+		{"draft.md", false, true},
+		{"assets", true, true},
+		{pathfilter.IgnoreFileName, false, false},
+	}
+	for _, tt := range tests {
+		if got := excluded(tt.name, tt.isDir); got != tt.want {
+			t.Errorf("excluded(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// flag filter relative to the current directory
+	if opts.filter, err = pathfilter.New(nil, []string{"bin/*.pdb"}); err != nil {
+		t.Fatal(err)
+	}
+	if excluded, err = opts.directoryFilter("bin"); err != nil || excluded == nil {
+		t.Fatalf("directoryFilter(bin) error = %v, want filter", err)
+	}
+	if !excluded("app.pdb", false) || excluded("app", false) {
+		t.Error("directoryFilter(bin) does not exclude app.pdb only")
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/registryutil"
+	"oras.land/oras/internal/signature"
+)
+
+type signOptions struct {
+	option.Common
+	option.Target
+	option.Format
+	option.Platform
+	option.Terminal
+
+	keyPath string
+}
+
+func signCmd() *cobra.Command {
+	var opts signOptions
+	cmd := &cobra.Command{
+		Use:   "sign [flags] --key=<path> <name>{:<tag>|@<digest>}",
+		Short: "[Experimental] Sign an artifact with a local ed25519 key",
+		Long: `[Experimental] Sign an artifact with a local ed25519 key
+
+The signature is attached to the artifact as a referrer of the artifact type
+"` + signature.ArtifactType + `" and can be verified via "oras verify".
+
+Example - Sign the manifest 'hello@sha256:9463e0d192846bc994279417b50114606712d516aab45f4d8b31cbc6e46aad71' with the PKCS #8 private key in 'ed25519.pem':
+  oras sign --key ed25519.pem localhost:5000/hello@sha256:9463e0d192846bc994279417b50114606712d516aab45f4d8b31cbc6e46aad71
+
+Example - Sign the manifest tagged 'v1' using a specific method for the Referrers API:
+  oras sign --key ed25519.pem --distribution-spec v1.1-referrers-tag localhost:5000/hello:v1
+
+Example - Sign a specific manifest with platform 'linux/amd64' in the multi-arch index 'hello:v1':
+  oras sign --key ed25519.pem --platform linux/amd64 localhost:5000/hello:v1
+
+Example - Sign the manifest tagged 'v1' in an OCI image layout folder 'layout-dir':
+  oras sign --key ed25519.pem --oci-layout layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the artifact to sign"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			opts.DisableTTY(opts.Debug, false)
+			return opts.EnsureReferenceNotEmpty(cmd, true)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSign(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.keyPath, "key", "", "", "`path` of the PEM-encoded PKCS #8 ed25519 private key")
+	_ = cmd.MarkFlagRequired("key")
+	opts.FlagDescription = "sign an arch-specific artifact"
+	opts.EnableDistributionSpecFlag()
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runSign(cmd *cobra.Command, opts *signOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	key, err := signature.LoadPrivateKey(opts.keyPath)
+	if err != nil {
+		return err
+	}
+
+	dst, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	// add both pull and push scope hints for dst repository
+	// to save potential push-scope token requests during copy
+	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
+	resolveOpts := oras.DefaultResolveOptions
+	resolveOpts.TargetPlatform = opts.Platform.Platform
+	subject, err := oras.Resolve(ctx, dst, opts.Reference, resolveOpts)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
+	}
+
+	// prepare signature
+	envelope, err := signature.Sign(key, subject)
+	if err != nil {
+		return err
+	}
+	store := memory.New()
+	desc := content.NewDescriptorFromBytes(signature.MediaType, envelope)
+	if err := store.Push(ctx, desc, bytes.NewReader(envelope)); err != nil {
+		return err
+	}
+	statusHandler, metadataHandler, err := display.NewAttachHandler(opts.Printer, opts.Format, opts.TTY, store)
+	if err != nil {
+		return err
+	}
+	packOpts := oras.PackManifestOptions{
+		Subject: &subject,
+		Layers:  []ocispec.Descriptor{desc},
+	}
+
+	// Sign
+	root, err := attachManifest(ctx, store, dst, signature.ArtifactType, packOpts, 1, statusHandler)
+	if err != nil {
+		return err
+	}
+	metadataHandler.OnAttached(&opts.Target, root, subject)
+	return metadataHandler.Render()
+}
@@ -0,0 +1,179 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/signature"
+)
+
+// maxSignatureManifestSize is the maximum size of a signature manifest to be
+// fetched.
+const maxSignatureManifestSize = 4 * 1024 * 1024
+
+type verifyOptions struct {
+	option.Common
+	option.Platform
+	option.Target
+	option.Format
+
+	keyPath string
+}
+
+func verifyCmd() *cobra.Command {
+	var opts verifyOptions
+	cmd := &cobra.Command{
+		Use:   "verify [flags] --key=<path> <name>{:<tag>|@<digest>}",
+		Short: "[Experimental] Verify the signatures of an artifact with a local ed25519 key",
+		Long: `[Experimental] Verify the signatures of an artifact with a local ed25519 key
+
+The signatures created via "oras sign" are discovered from the referrers of the
+artifact. The command fails if none of them is valid for the key.
+
+Example - Verify the manifest tagged 'v1' with the PKIX public key in 'pub.pem':
+  oras verify --key pub.pem localhost:5000/hello:v1
+
+Example - Verify a specific manifest with platform 'linux/amd64' in the multi-arch index 'hello:v1':
+  oras verify --key pub.pem --platform linux/amd64 localhost:5000/hello:v1
+
+Example - Verify the manifest tagged 'v1' in an OCI image layout folder 'layout-dir':
+  oras verify --key pub.pem --oci-layout layout-dir:v1
+
+Example - Verify the manifest tagged 'v1' and print the result in JSON:
+  oras verify --key pub.pem --format json localhost:5000/hello:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the artifact to verify"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerify(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.keyPath, "key", "", "", "`path` of the PEM-encoded PKIX ed25519 public key")
+	_ = cmd.MarkFlagRequired("key")
+	opts.FlagDescription = "verify an arch-specific artifact"
+	opts.EnableDistributionSpecFlag()
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runVerify(cmd *cobra.Command, opts *verifyOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	key, err := signature.LoadPublicKey(opts.keyPath)
+	if err != nil {
+		return err
+	}
+	repo, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	resolveOpts := oras.DefaultResolveOptions
+	resolveOpts.TargetPlatform = opts.Platform.Platform
+	subject, err := oras.Resolve(ctx, repo, opts.Reference, resolveOpts)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
+	}
+
+	signatures, err := registry.Referrers(ctx, repo, subject, signature.ArtifactType)
+	if err != nil {
+		return err
+	}
+	handler, err := display.NewVerifyHandler(opts.Printer, opts.Format, &opts.Target, subject)
+	if err != nil {
+		return err
+	}
+	var verified int
+	for _, sig := range signatures {
+		err := verifySignature(ctx, repo, key, subject, sig)
+		if err == nil {
+			verified++
+		}
+		if err := handler.OnSignatureVerified(sig, err); err != nil {
+			return err
+		}
+	}
+	if err := handler.Render(); err != nil {
+		return err
+	}
+	if verified == 0 {
+		recommendation := "the artifact may be signed by another key"
+		if len(signatures) == 0 {
+			recommendation = `sign the artifact via "oras sign"`
+		}
+		return &oerrors.Error{
+			Err:            fmt.Errorf("no valid signature found for %s among %d signature(s)", opts.GetDisplayReference(), len(signatures)),
+			Recommendation: recommendation,
+		}
+	}
+	return nil
+}
+
+// verifySignature verifies the signature manifest sig against the subject. It
+// succeeds if any signature layer is valid for the key.
+func verifySignature(ctx context.Context, fetcher content.Fetcher, key ed25519.PublicKey, subject ocispec.Descriptor, sig ocispec.Descriptor) error {
+	if sig.Size > maxSignatureManifestSize {
+		return fmt.Errorf("signature manifest size %d exceeds the limit %d", sig.Size, maxSignatureManifestSize)
+	}
+	manifestJSON, err := content.FetchAll(ctx, fetcher, sig)
+	if err != nil {
+		return err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return fmt.Errorf("malformed signature manifest: %w", err)
+	}
+	err = errors.New("no signature layer found")
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != signature.MediaType {
+			continue
+		}
+		if layer.Size > signature.MaxEnvelopeSize {
+			err = fmt.Errorf("signature size %d exceeds the limit %d", layer.Size, signature.MaxEnvelopeSize)
+			continue
+		}
+		var envelope []byte
+		envelope, err = content.FetchAll(ctx, fetcher, layer)
+		if err != nil {
+			return err
+		}
+		if err = signature.Verify(key, subject, envelope); err == nil {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// locksDir is the directory of the lock files under the cache root.
+const locksDir = "locks"
+
+// lockRetryInterval is the interval between attempts to acquire a lock held by
+// another process or goroutine.
+const lockRetryInterval = 50 * time.Millisecond
+
+// fileLock is an exclusive lock across processes, backed by a lock file.
+type fileLock struct {
+	fp *os.File
+}
+
+// lock acquires the exclusive lock of the file at path, waiting until the lock
+// is released by other processes or goroutines, or ctx is done. The lock file
+// is created if not exists. Since the lock file may be removed by its holder,
+// the lock is acquired only if the locked file is still at path.
+func lock(ctx context.Context, path string) (*fileLock, error) {
+	fp, err := openLockFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		locked, err := tryLock(fp)
+		if err != nil {
+			_ = fp.Close()
+			return nil, err
+		}
+		if locked {
+			removed, err := isRemoved(fp, path)
+			if err != nil {
+				_ = unlock(fp)
+				_ = fp.Close()
+				return nil, err
+			}
+			if !removed {
+				return &fileLock{fp: fp}, nil
+			}
+			// retry with the new lock file at path
+			_ = unlock(fp)
+			_ = fp.Close()
+			if fp, err = openLockFile(path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			_ = fp.Close()
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// openLockFile opens the lock file at path, creating it if not exists.
+func openLockFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0666)
+}
+
+// isRemoved returns true if the opened lock file is no longer at path.
+func isRemoved(fp *os.File, path string) (bool, error) {
+	fi, err := fp.Stat()
+	if err != nil {
+		return false, err
+	}
+	current, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return true, nil
+		}
+		return false, err
+	}
+	return !os.SameFile(fi, current), nil
+}
+
+// Unlock releases the lock.
+func (l *fileLock) Unlock() error {
+	err := unlock(l.fp)
+	if closeErr := l.fp.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Remove removes the lock file and releases the lock. Processes waiting for
+// the lock acquire it with a new lock file.
+func (l *fileLock) Remove() error {
+	// the lock file is removed while locked if the file system allows,
+	// otherwise after closed, which fails if the file is opened by others
+	path := l.fp.Name()
+	removeErr := os.Remove(path)
+	if err := l.Unlock(); err != nil {
+		return err
+	}
+	if removeErr != nil {
+		// best effort, as the lock file is harmless if left
+		_ = os.Remove(path)
+	}
+	return nil
+}
+
+// lockPath returns the path of the lock file of the blob in the cache at root.
+func lockPath(root string, dgst digest.Digest) string {
+	return filepath.Join(root, locksDir, dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// storeLockPath returns the path of the lock file of the OCI image layout of
+// the cache at root.
+func storeLockPath(root string) string {
+	return filepath.Join(root, locksDir, "store")
+}
@@ -0,0 +1,217 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "locks", "test")
+	l, err := lock(context.Background(), path)
+	if err != nil {
+		t.Fatal("lock() error =", err)
+	}
+
+	// the lock is held
+	ctx, cancel := context.WithTimeout(context.Background(), 3*lockRetryInterval)
+	defer cancel()
+	if _, err := lock(ctx, path); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("lock() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// the lock is acquired once released
+	acquired := make(chan error)
+	go func() {
+		l, err := lock(context.Background(), path)
+		if err == nil {
+			err = l.Unlock()
+		}
+		acquired <- err
+	}()
+	if err := l.Unlock(); err != nil {
+		t.Fatal("Unlock() error =", err)
+	}
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Fatal("lock() error =", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("lock() not acquired after released")
+	}
+}
+
+func TestFileLock_Remove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "locks", "test")
+	l, err := lock(context.Background(), path)
+	if err != nil {
+		t.Fatal("lock() error =", err)
+	}
+
+	// the waiting process acquires the lock with a new lock file
+	acquired := make(chan *fileLock)
+	go func() {
+		l, err := lock(context.Background(), path)
+		if err != nil {
+			t.Error("lock() error =", err)
+		}
+		acquired <- l
+	}()
+	time.Sleep(2 * lockRetryInterval)
+	if err := l.Remove(); err != nil {
+		t.Fatal("Remove() error =", err)
+	}
+	var waiter *fileLock
+	select {
+	case waiter = <-acquired:
+		if waiter == nil {
+			t.FailNow()
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("lock() not acquired after removed")
+	}
+	defer func() { _ = waiter.Unlock() }()
+	if _, err := os.Stat(path); err != nil {
+		t.Fatal("lock file not recreated:", err)
+	}
+
+	// the lock is held by the waiter
+	ctx, cancel := context.WithTimeout(context.Background(), 3*lockRetryInterval)
+	defer cancel()
+	if _, err := lock(ctx, path); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("lock() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// countingTarget counts the fetches from the origin.
+type countingTarget struct {
+	oras.ReadOnlyTarget
+	fetched atomic.Int64
+}
+
+func (t *countingTarget) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	t.fetched.Add(1)
+	return t.ReadOnlyTarget.Fetch(ctx, desc)
+}
+
+func TestNew_sharedCache(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("hello world")
+	desc := content.NewDescriptorFromBytes("test", blob)
+	origin := memory.New()
+	if err := origin.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+	source := &countingTarget{ReadOnlyTarget: origin}
+	root := t.TempDir()
+
+	// each target has its own store as if in a separate process
+	var targets []oras.ReadOnlyTarget
+	for range 2 {
+		store, err := NewStore(root)
+		if err != nil {
+			t.Fatal("NewStore() error =", err)
+		}
+		targets = append(targets, New(source, store))
+	}
+
+	// the lock is not held while downloading
+	rc, err := targets[0].Fetch(ctx, desc)
+	if err != nil {
+		t.Fatal("Fetch() error =", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		got, err := content.FetchAll(ctx, targets[1], desc)
+		if err == nil && !bytes.Equal(got, blob) {
+			err = fmt.Errorf("Fetch() = %s, want %s", got, blob)
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Fetch() blocked by the download of another process")
+	}
+
+	// the download finished later is not stored again
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.Close(); err != nil {
+		t.Fatal("Close() error =", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("Fetch() = %s, want %s", got, blob)
+	}
+	if fetched := source.fetched.Load(); fetched != 2 {
+		t.Errorf("fetched from origin %d times, want 2", fetched)
+	}
+	blobs, err := List(root)
+	if err != nil || len(blobs) != 1 || blobs[0].Digest != desc.Digest {
+		t.Errorf("List() = %v, %v, want %s", blobs, err, desc.Digest)
+	}
+}
+
+func TestStore_Push_locked(t *testing.T) {
+	root := t.TempDir()
+	s, err := NewStore(root)
+	if err != nil {
+		t.Fatal("NewStore() error =", err)
+	}
+	blob := []byte("hello world")
+	desc := content.NewDescriptorFromBytes("test", blob)
+	l, err := lock(context.Background(), lockPath(root, desc.Digest))
+	if err != nil {
+		t.Fatal("lock() error =", err)
+	}
+
+	// storing waits for the lock until ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 3*lockRetryInterval)
+	defer cancel()
+	if err := s.Push(ctx, desc, bytes.NewReader(blob)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Push() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := l.Unlock(); err != nil {
+		t.Fatal("Unlock() error =", err)
+	}
+	if err := s.Push(context.Background(), desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(root, ingestDir))
+	if err != nil || len(entries) != 0 {
+		t.Errorf("ingest directory = %v, %v, want empty", entries, err)
+	}
+}
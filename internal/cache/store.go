@@ -0,0 +1,391 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+// ingestDir is the directory of the content being downloaded under the cache
+// root.
+const ingestDir = "ingest"
+
+// ErrNotCache is returned when a directory is not an ORAS cache.
+var ErrNotCache = errors.New("not an ORAS cache directory")
+
+// Blob describes a blob in the cache.
+type Blob struct {
+	// Digest is the digest of the blob.
+	Digest digest.Digest
+	// Size is the size of the blob in bytes.
+	Size int64
+	// LastAccess is the last time the blob was stored or fetched.
+	LastAccess time.Time
+	// Path is the path of the blob file.
+	Path string
+}
+
+// store records the last access time of the blobs in the cache as the
+// modification times of the blob files, since access times are not reliably
+// maintained by file systems.
+type store struct {
+	*oci.Store
+	root string
+}
+
+// NewStore returns the storage of the cache at root. The cache may be shared
+// by concurrent processes.
+func NewStore(root string) (content.Storage, error) {
+	// the OCI image layout is initialized by one process at a time
+	l, err := lock(context.Background(), storeLockPath(root))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = l.Unlock() }()
+	ociStore, err := oci.New(root)
+	if err != nil {
+		return nil, err
+	}
+	return &store{
+		Store: ociStore,
+		root:  root,
+	}, nil
+}
+
+// Fetch fetches the content identified by the descriptor and marks it as
+// accessed.
+func (s *store) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	rc, err := s.Store.Fetch(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if target.Digest.Validate() == nil {
+		now := time.Now()
+		_ = os.Chtimes(blobPath(s.root, target.Digest), now, now)
+	}
+	return rc, nil
+}
+
+// Push downloads the content into a temporary file and then stores it. Only
+// storing the downloaded content is locked across processes, so that the
+// transfer of the same blob by concurrent processes is not serialized and
+// the one stored first is kept.
+func (s *store) Push(ctx context.Context, expected ocispec.Descriptor, reader io.Reader) error {
+	dir := filepath.Join(s.root, ingestDir)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	fp, err := os.CreateTemp(dir, expected.Digest.Encoded()+"_*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = fp.Close()
+		_ = os.Remove(fp.Name())
+	}()
+	vr := content.NewVerifyReader(reader, expected)
+	if _, err := io.Copy(fp, vr); err != nil {
+		return err
+	}
+	if err := vr.Verify(); err != nil {
+		return err
+	}
+	if _, err := fp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	l, err := lock(ctx, lockPath(s.root, expected.Digest))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = l.Unlock() }()
+	if exists, err := s.Store.Exists(ctx, expected); err != nil || exists {
+		// stored by another process while downloading
+		return err
+	}
+	return s.Store.Push(ctx, expected, fp)
+}
+
+// blobPath returns the path of the blob in the cache at root.
+func blobPath(root string, dgst digest.Digest) string {
+	return filepath.Join(root, ocispec.ImageBlobsDir, dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// List lists the blobs in the cache at root, ordered from the least recently
+// accessed.
+func List(root string) ([]Blob, error) {
+	if err := checkCache(root); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// the cache is not created yet
+			return nil, nil
+		}
+		return nil, err
+	}
+	var blobs []Blob
+	blobsDir := filepath.Join(root, ocispec.ImageBlobsDir)
+	err := filepath.WalkDir(blobsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == blobsDir && errors.Is(err, fs.ErrNotExist) {
+				// empty cache
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(blobsDir, path)
+		if err != nil {
+			return err
+		}
+		alg, encoded := filepath.Split(rel)
+		dgst := digest.NewDigestFromEncoded(digest.Algorithm(filepath.Clean(alg)), encoded)
+		if dgst.Validate() != nil {
+			// not a blob
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, Blob{
+			Digest:     dgst,
+			Size:       fi.Size(),
+			LastAccess: fi.ModTime(),
+			Path:       path,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(blobs, func(a, b Blob) int {
+		return a.LastAccess.Compare(b.LastAccess)
+	})
+	return blobs, nil
+}
+
+// SelectEvictions selects the blobs to be evicted from the blobs ordered from
+// the least recently accessed. Blobs last accessed before the given time are
+// selected if the time is not zero. Then, the least recently accessed blobs
+// are selected until the total size of the rest is within maxSize if maxSize
+// is not negative.
+func SelectEvictions(blobs []Blob, before time.Time, maxSize int64) []Blob {
+	var total int64
+	for _, blob := range blobs {
+		total += blob.Size
+	}
+	var evicted []Blob
+	for _, blob := range blobs {
+		if (before.IsZero() || !blob.LastAccess.Before(before)) && (maxSize < 0 || total <= maxSize) {
+			continue
+		}
+		evicted = append(evicted, blob)
+		total -= blob.Size
+	}
+	return evicted
+}
+
+// Remove removes the blob from the cache at root. If the blob is a manifest,
+// the cached references resolved to it and its entries in the index are
+// removed as well, so that they do not point to the evicted blob.
+func Remove(ctx context.Context, root string, blob Blob) error {
+	// wait for the blob being stored by other processes
+	l, err := lock(ctx, lockPath(root, blob.Digest))
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(blob.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		_ = l.Unlock()
+		return err
+	}
+	// the blob lock is released before locking the store to avoid deadlocks
+	// with Clear, which locks the blobs while holding the store lock
+	if err := l.Remove(); err != nil {
+		return err
+	}
+	if err := removeTags(root, blob.Digest); err != nil {
+		return err
+	}
+	return removeIndexEntries(ctx, root, blob.Digest)
+}
+
+// removeIndexEntries removes the entries of the digest from the index of the
+// cache at root.
+func removeIndexEntries(ctx context.Context, root string, dgst digest.Digest) error {
+	l, err := lock(ctx, storeLockPath(root))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = l.Unlock() }()
+	path := filepath.Join(root, ocispec.ImageIndexFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	manifests := slices.DeleteFunc(slices.Clone(index.Manifests), func(desc ocispec.Descriptor) bool {
+		return desc.Digest == dgst
+	})
+	if len(manifests) == len(index.Manifests) {
+		return nil
+	}
+	index.Manifests = manifests
+	if data, err = json.Marshal(index); err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
+
+// RemoveStaleLocks removes the lock files of the blobs not in the cache at
+// root, which are left by interrupted or failed downloads.
+func RemoveStaleLocks(ctx context.Context, root string) error {
+	return removeBlobLocks(ctx, root, true)
+}
+
+// Clear removes the cache at root, waiting for the blobs being stored by
+// other processes.
+func Clear(ctx context.Context, root string) error {
+	if err := checkCache(root); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	l, err := lock(ctx, storeLockPath(root))
+	if err != nil {
+		return err
+	}
+	if err := removeBlobLocks(ctx, root, false); err != nil {
+		_ = l.Unlock()
+		return err
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		_ = l.Unlock()
+		return err
+	}
+	for _, e := range entries {
+		if e.Name() == locksDir {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(root, e.Name())); err != nil {
+			_ = l.Unlock()
+			return err
+		}
+	}
+	if err := l.Remove(); err != nil {
+		return err
+	}
+	// the directories are kept if other processes start using the cache
+	_ = os.Remove(filepath.Join(root, locksDir))
+	_ = os.Remove(root)
+	return nil
+}
+
+// removeBlobLocks removes the lock files of the blobs in the cache at root,
+// except the ones of the cached blobs if keepCached is true. Each lock is
+// acquired before removal, so that blobs being stored are waited for.
+func removeBlobLocks(ctx context.Context, root string, keepCached bool) error {
+	dir := filepath.Join(root, locksDir)
+	algs, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, alg := range algs {
+		if !alg.IsDir() {
+			continue
+		}
+		algDir := filepath.Join(dir, alg.Name())
+		entries, err := os.ReadDir(algDir)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			dgst := digest.NewDigestFromEncoded(digest.Algorithm(alg.Name()), e.Name())
+			if dgst.Validate() != nil {
+				continue
+			}
+			if keepCached && isCached(root, dgst) {
+				continue
+			}
+			l, err := lock(ctx, lockPath(root, dgst))
+			if err != nil {
+				return err
+			}
+			if keepCached && isCached(root, dgst) {
+				// stored while waiting for the lock
+				if err := l.Unlock(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := l.Remove(); err != nil {
+				return err
+			}
+		}
+		// removed only if empty
+		_ = os.Remove(algDir)
+	}
+	return nil
+}
+
+// isCached returns true if the blob is in the cache at root.
+func isCached(root string, dgst digest.Digest) bool {
+	_, err := os.Stat(blobPath(root, dgst))
+	return err == nil
+}
+
+// checkCache ensures root is a cache directory, which is an OCI image layout.
+func checkCache(root string) error {
+	fi, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%s: %w", root, ErrNotCache)
+	}
+	if _, err := os.Stat(filepath.Join(root, ocispec.ImageLayoutFile)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%s: %w", root, ErrNotCache)
+		}
+		return err
+	}
+	return nil
+}
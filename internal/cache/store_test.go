@@ -0,0 +1,217 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/content"
+)
+
+func pushBlob(t *testing.T, s content.Storage, root string, blob string, lastAccess time.Time) Blob {
+	t.Helper()
+	desc := content.NewDescriptorFromBytes("test", []byte(blob))
+	if err := s.Push(context.Background(), desc, bytes.NewReader([]byte(blob))); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+	path := blobPath(root, desc.Digest)
+	if err := os.Chtimes(path, lastAccess, lastAccess); err != nil {
+		t.Fatal(err)
+	}
+	return Blob{
+		Digest:     desc.Digest,
+		Size:       desc.Size,
+		LastAccess: lastAccess,
+		Path:       path,
+	}
+}
+
+func TestStore(t *testing.T) {
+	root := t.TempDir()
+	s, err := NewStore(root)
+	if err != nil {
+		t.Fatal("NewStore() error =", err)
+	}
+	now := time.Now().Truncate(time.Second)
+	newer := pushBlob(t, s, root, "newer", now.Add(-time.Hour))
+	older := pushBlob(t, s, root, "older", now.Add(-2*time.Hour))
+
+	blobs, err := List(root)
+	if err != nil {
+		t.Fatal("List() error =", err)
+	}
+	if want := []Blob{older, newer}; !reflect.DeepEqual(blobs, want) {
+		t.Fatalf("List() = %v, want %v", blobs, want)
+	}
+
+	// fetching marks the blob as accessed
+	rc, err := s.Fetch(context.Background(), content.NewDescriptorFromBytes("test", []byte("older")))
+	if err != nil {
+		t.Fatal("Fetch() error =", err)
+	}
+	if _, err := io.ReadAll(rc); err != nil {
+		t.Fatal(err)
+	}
+	_ = rc.Close()
+	if blobs, err = List(root); err != nil {
+		t.Fatal("List() error =", err)
+	}
+	if len(blobs) != 2 || blobs[1].Digest != older.Digest || blobs[1].LastAccess.Before(now) {
+		t.Errorf("List() = %v, want %s accessed last", blobs, older.Digest)
+	}
+
+	if err := Remove(context.Background(), root, blobs[0]); err != nil {
+		t.Fatal("Remove() error =", err)
+	}
+	if blobs, err = List(root); err != nil || len(blobs) != 1 {
+		t.Errorf("List() = %v, %v, want 1 blob", blobs, err)
+	}
+	if err := Clear(context.Background(), root); err != nil {
+		t.Fatal("Clear() error =", err)
+	}
+	if _, err := os.Stat(root); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("cache not removed: %v", err)
+	}
+	if blobs, err = List(root); err != nil || len(blobs) != 0 {
+		t.Errorf("List() = %v, %v, want no blob", blobs, err)
+	}
+}
+
+func TestRemoveStaleLocks(t *testing.T) {
+	root := t.TempDir()
+	s, err := NewStore(root)
+	if err != nil {
+		t.Fatal("NewStore() error =", err)
+	}
+	cached := pushBlob(t, s, root, "cached", time.Now())
+	stale := content.NewDescriptorFromBytes("test", []byte("stale")).Digest
+	for _, dgst := range []digest.Digest{cached.Digest, stale} {
+		l, err := lock(context.Background(), lockPath(root, dgst))
+		if err != nil {
+			t.Fatal("lock() error =", err)
+		}
+		if err := l.Unlock(); err != nil {
+			t.Fatal("Unlock() error =", err)
+		}
+	}
+
+	if err := RemoveStaleLocks(context.Background(), root); err != nil {
+		t.Fatal("RemoveStaleLocks() error =", err)
+	}
+	if _, err := os.Stat(lockPath(root, cached.Digest)); err != nil {
+		t.Errorf("lock of cached blob removed: %v", err)
+	}
+	if _, err := os.Stat(lockPath(root, stale)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("stale lock not removed: %v", err)
+	}
+
+	if err := Remove(context.Background(), root, cached); err != nil {
+		t.Fatal("Remove() error =", err)
+	}
+	if _, err := os.Stat(lockPath(root, cached.Digest)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("lock of removed blob not removed: %v", err)
+	}
+}
+
+func TestClear_locked(t *testing.T) {
+	root := t.TempDir()
+	s, err := NewStore(root)
+	if err != nil {
+		t.Fatal("NewStore() error =", err)
+	}
+	blob := pushBlob(t, s, root, "blob", time.Now())
+
+	// another process is storing the blob
+	l, err := lock(context.Background(), lockPath(root, blob.Digest))
+	if err != nil {
+		t.Fatal("lock() error =", err)
+	}
+	cleared := make(chan error)
+	go func() {
+		cleared <- Clear(context.Background(), root)
+	}()
+	select {
+	case err := <-cleared:
+		t.Fatalf("Clear() = %v, want waiting for the blob lock", err)
+	case <-time.After(3 * lockRetryInterval):
+	}
+	if err := l.Unlock(); err != nil {
+		t.Fatal("Unlock() error =", err)
+	}
+	select {
+	case err := <-cleared:
+		if err != nil {
+			t.Fatal("Clear() error =", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Clear() not done after unlocked")
+	}
+	if _, err := os.Stat(root); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("cache not removed: %v", err)
+	}
+}
+
+func TestClear_notCache(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "file"), []byte("keep"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := Clear(context.Background(), root); !errors.Is(err, ErrNotCache) {
+		t.Errorf("Clear() error = %v, want %v", err, ErrNotCache)
+	}
+	if _, err := os.Stat(filepath.Join(root, "file")); err != nil {
+		t.Errorf("file removed: %v", err)
+	}
+}
+
+func TestSelectEvictions(t *testing.T) {
+	now := time.Now()
+	blobs := []Blob{
+		{Digest: "sha256:a", Size: 10, LastAccess: now.Add(-72 * time.Hour)},
+		{Digest: "sha256:b", Size: 20, LastAccess: now.Add(-48 * time.Hour)},
+		{Digest: "sha256:c", Size: 30, LastAccess: now.Add(-time.Hour)},
+	}
+	tests := []struct {
+		name    string
+		before  time.Time
+		maxSize int64
+		want    []Blob
+	}{
+		{"none", time.Time{}, -1, nil},
+		{"older than", now.Add(-24 * time.Hour), -1, blobs[:2]},
+		{"max size", time.Time{}, 35, blobs[:2]},
+		{"max size fits", time.Time{}, 60, nil},
+		{"max size zero", time.Time{}, 0, blobs},
+		{"both", now.Add(-60 * time.Hour), 50, blobs[:1]},
+		{"both by size", now.Add(-60 * time.Hour), 30, blobs[:2]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SelectEvictions(blobs, tt.before, tt.maxSize); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectEvictions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
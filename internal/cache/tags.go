@@ -0,0 +1,248 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// tagsDir is the directory of the cached references under the cache root.
+const tagsDir = "tags"
+
+// ErrCacheMiss is returned in offline mode if the requested content or
+// reference is not in the cache.
+var ErrCacheMiss = errors.New("not found in the cache")
+
+// TagOptions configures how references are resolved with the cache.
+type TagOptions struct {
+	// Repository is the repository of the references, e.g.
+	// "localhost:5000/hello".
+	Repository string
+	// TTL is the duration within which a cached tag is used without
+	// resolving it from the origin. Tags are always resolved from the origin
+	// if TTL is zero.
+	TTL time.Duration
+	// Offline resolves references and fetches content only from the cache.
+	Offline bool
+}
+
+// tagEntry is a cached reference.
+type tagEntry struct {
+	Reference  string             `json:"reference"`
+	Descriptor ocispec.Descriptor `json:"descriptor"`
+	ResolvedAt time.Time          `json:"resolvedAt"`
+}
+
+// tagTarget is a cached target recording the resolved references of a
+// repository.
+type tagTarget struct {
+	*target
+	root string
+	opts TagOptions
+}
+
+// NewWithTags generates a new target storage of a repository with the cache
+// at root, where the resolved references are recorded with their resolution
+// time. Cached tags are used within opts.TTL, and cached digest references are
+// always used since they never change.
+func NewWithTags(source oras.ReadOnlyTarget, root string, opts TagOptions) (oras.ReadOnlyTarget, error) {
+	store, err := NewStore(root)
+	if err != nil {
+		return nil, err
+	}
+	return &tagTarget{
+		target: &target{
+			ReadOnlyTarget: source,
+			cache:          store,
+		},
+		root: root,
+		opts: opts,
+	}, nil
+}
+
+// Resolve resolves a reference to a descriptor, from the cache if the cached
+// reference is fresh or in offline mode.
+func (t *tagTarget) Resolve(ctx context.Context, reference string) (ocispec.Descriptor, error) {
+	if desc, ok := t.resolveCached(reference); ok {
+		return desc, nil
+	}
+	if t.opts.Offline {
+		return ocispec.Descriptor{}, fmt.Errorf("%s: %w", t.fullReference(reference), ErrCacheMiss)
+	}
+	desc, err := t.ReadOnlyTarget.Resolve(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	// the cache is best-effort
+	_ = t.saveTag(reference, desc)
+	return desc, nil
+}
+
+// FetchReference fetches the content identified by the reference.
+func (t *tagTarget) FetchReference(ctx context.Context, reference string) (ocispec.Descriptor, io.ReadCloser, error) {
+	desc, err := t.Resolve(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	rc, err := t.Fetch(ctx, desc)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	return desc, rc, nil
+}
+
+// Fetch fetches the content identified by the descriptor, only from the cache
+// in offline mode.
+func (t *tagTarget) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	if !t.opts.Offline {
+		return t.target.Fetch(ctx, desc)
+	}
+	rc, err := t.cache.Fetch(ctx, desc)
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", desc.Digest, ErrCacheMiss)
+		}
+		return nil, err
+	}
+	return rc, nil
+}
+
+// Exists returns true if the described content exists, only in the cache in
+// offline mode.
+func (t *tagTarget) Exists(ctx context.Context, desc ocispec.Descriptor) (bool, error) {
+	if !t.opts.Offline {
+		return t.target.Exists(ctx, desc)
+	}
+	return t.cache.Exists(ctx, desc)
+}
+
+// resolveCached resolves the reference from the cache.
+func (t *tagTarget) resolveCached(reference string) (ocispec.Descriptor, bool) {
+	_, err := digest.Parse(reference)
+	isTag := err != nil
+	if !t.opts.Offline && isTag && t.opts.TTL <= 0 {
+		return ocispec.Descriptor{}, false
+	}
+	data, err := os.ReadFile(t.tagPath(reference))
+	if err != nil {
+		return ocispec.Descriptor{}, false
+	}
+	var entry tagEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Reference != t.fullReference(reference) {
+		return ocispec.Descriptor{}, false
+	}
+	if !t.opts.Offline && isTag && time.Since(entry.ResolvedAt) >= t.opts.TTL {
+		return ocispec.Descriptor{}, false
+	}
+	return entry.Descriptor, true
+}
+
+// saveTag records the resolved reference.
+func (t *tagTarget) saveTag(reference string, desc ocispec.Descriptor) error {
+	data, err := json.Marshal(tagEntry{
+		Reference:  t.fullReference(reference),
+		Descriptor: desc,
+		ResolvedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	path := t.tagPath(reference)
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
+
+// fullReference returns the reference qualified by the repository.
+func (t *tagTarget) fullReference(reference string) string {
+	if _, err := digest.Parse(reference); err == nil {
+		return t.opts.Repository + "@" + reference
+	}
+	return t.opts.Repository + ":" + reference
+}
+
+// tagPath returns the path of the cached reference.
+func (t *tagTarget) tagPath(reference string) string {
+	return filepath.Join(t.root, tagsDir, digest.FromString(t.fullReference(reference)).Encoded()+".json")
+}
+
+// removeTags removes the cached references resolved to the digest from the
+// cache at root.
+func removeTags(root string, dgst digest.Digest) error {
+	dir := filepath.Join(root, tagsDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, e := range entries {
+		if !e.Type().IsRegular() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		var entry tagEntry
+		if err := json.Unmarshal(data, &entry); err != nil || entry.Descriptor.Digest != dgst {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile writes the data to the file at path atomically, so that
+// concurrent readers never see a partially written file.
+func writeFile(path string, data []byte) error {
+	fp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = fp.Write(data)
+	if closeErr := fp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(fp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(fp.Name())
+	}
+	return err
+}
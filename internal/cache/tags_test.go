@@ -0,0 +1,183 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func pushTagged(t *testing.T, s *memory.Store, data string, tag string) ocispec.Descriptor {
+	t.Helper()
+	ctx := context.Background()
+	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte(data))
+	if exists, _ := s.Exists(ctx, desc); !exists {
+		if err := s.Push(ctx, desc, bytes.NewReader([]byte(data))); err != nil {
+			t.Fatal("Push() error =", err)
+		}
+	}
+	if err := s.Tag(ctx, desc, tag); err != nil {
+		t.Fatal("Tag() error =", err)
+	}
+	return desc
+}
+
+func TestNewWithTags(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	source := memory.New()
+	v1 := pushTagged(t, source, `{"schemaVersion":2,"annotations":{"version":"v1"}}`, "latest")
+	const repo = "localhost:5000/test"
+
+	// record the tag while online
+	online, err := NewWithTags(source, root, TagOptions{Repository: repo})
+	if err != nil {
+		t.Fatal("NewWithTags() error =", err)
+	}
+	_, rc, err := online.(*tagTarget).FetchReference(ctx, "latest")
+	if err != nil {
+		t.Fatal("FetchReference() error =", err)
+	}
+	if _, err := io.ReadAll(rc); err != nil {
+		t.Fatal(err)
+	}
+	_ = rc.Close()
+
+	// the tag is moved at the origin
+	v2 := pushTagged(t, source, `{"schemaVersion":2,"annotations":{"version":"v2"}}`, "latest")
+
+	tests := []struct {
+		name string
+		opts TagOptions
+		want ocispec.Descriptor
+	}{
+		{"within ttl", TagOptions{Repository: repo, TTL: time.Hour}, v1},
+		{"offline", TagOptions{Repository: repo, Offline: true}, v1},
+		{"no ttl", TagOptions{Repository: repo}, v2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := NewWithTags(source, root, tt.opts)
+			if err != nil {
+				t.Fatal("NewWithTags() error =", err)
+			}
+			got, err := target.Resolve(ctx, "latest")
+			if err != nil {
+				t.Fatal("Resolve() error =", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// "no ttl" has recorded the moved tag
+	offline, err := NewWithTags(source, root, TagOptions{Repository: repo, Offline: true})
+	if err != nil {
+		t.Fatal("NewWithTags() error =", err)
+	}
+	if got, err := offline.Resolve(ctx, "latest"); err != nil || !reflect.DeepEqual(got, v2) {
+		t.Errorf("Resolve() = %v, %v, want %v", got, err, v2)
+	}
+	// v1 is fetched into the cache but v2 is not
+	if _, err := offline.Fetch(ctx, v1); err != nil {
+		t.Error("Fetch() error =", err)
+	}
+	if _, err := offline.Fetch(ctx, v2); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Fetch() error = %v, want %v", err, ErrCacheMiss)
+	}
+	if _, err := offline.Resolve(ctx, "unknown"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Resolve() error = %v, want %v", err, ErrCacheMiss)
+	}
+	// tags are recorded per repository
+	other, err := NewWithTags(source, root, TagOptions{Repository: "localhost:5000/other", Offline: true})
+	if err != nil {
+		t.Fatal("NewWithTags() error =", err)
+	}
+	if _, err := other.Resolve(ctx, "latest"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Resolve() error = %v, want %v", err, ErrCacheMiss)
+	}
+}
+
+func TestRemove_manifest(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	source := memory.New()
+	desc := pushTagged(t, source, `{"schemaVersion":2}`, "latest")
+	const repo = "localhost:5000/test"
+
+	target, err := NewWithTags(source, root, TagOptions{Repository: repo, TTL: time.Hour})
+	if err != nil {
+		t.Fatal("NewWithTags() error =", err)
+	}
+	_, rc, err := target.(*tagTarget).FetchReference(ctx, "latest")
+	if err != nil {
+		t.Fatal("FetchReference() error =", err)
+	}
+	if _, err := io.ReadAll(rc); err != nil {
+		t.Fatal(err)
+	}
+	_ = rc.Close()
+	if err := target.(*tagTarget).saveTag(desc.Digest.String(), desc); err != nil {
+		t.Fatal("saveTag() error =", err)
+	}
+	tags, err := os.ReadDir(filepath.Join(root, tagsDir))
+	if err != nil || len(tags) != 2 {
+		t.Fatalf("ReadDir() = %v, %v, want 2 tags", tags, err)
+	}
+
+	blobs, err := List(root)
+	if err != nil || len(blobs) != 1 {
+		t.Fatalf("List() = %v, %v, want 1 blob", blobs, err)
+	}
+	if err := Remove(context.Background(), root, blobs[0]); err != nil {
+		t.Fatal("Remove() error =", err)
+	}
+	if tags, err = os.ReadDir(filepath.Join(root, tagsDir)); err != nil || len(tags) != 0 {
+		t.Errorf("ReadDir() = %v, %v, want no tag", tags, err)
+	}
+	data, err := os.ReadFile(filepath.Join(root, ocispec.ImageIndexFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Manifests) != 0 {
+		t.Errorf("index manifests = %v, want none", index.Manifests)
+	}
+
+	offline, err := NewWithTags(source, root, TagOptions{Repository: repo, Offline: true})
+	if err != nil {
+		t.Fatal("NewWithTags() error =", err)
+	}
+	if _, err := offline.Resolve(ctx, "latest"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Resolve() error = %v, want %v", err, ErrCacheMiss)
+	}
+}
@@ -61,7 +61,8 @@ func (t *target) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadC
 		return rc, nil
 	}
 
-	if rc, err = t.ReadOnlyTarget.Fetch(ctx, target); err != nil {
+	rc, err = t.ReadOnlyTarget.Fetch(ctx, target)
+	if err != nil {
 		return nil, err
 	}
 
@@ -130,6 +131,7 @@ func (t *referenceTarget) FetchReference(ctx context.Context, reference string)
 	// skip caching if the content already exists in cache
 	exists, err := t.cache.Exists(ctx, target)
 	if err != nil {
+		_ = rc.Close()
 		return ocispec.Descriptor{}, nil, err
 	}
 	if exists {
@@ -151,3 +153,44 @@ func (t *referenceTarget) FetchReference(ctx context.Context, reference string)
 	// Fetch from origin with caching
 	return target, t.cacheReadCloser(ctx, rc, target), nil
 }
+
+// ReadOnlyRepository is a read-only repository supporting the referrers API,
+// such as a remote repository.
+type ReadOnlyRepository interface {
+	oras.ReadOnlyGraphTarget
+	registry.ReferenceFetcher
+	registry.ReferrerLister
+}
+
+// graphTarget is a cached target of a repository. Only the content is read
+// through the cache, while the graph queries are always sent to the
+// repository.
+type graphTarget struct {
+	*referenceTarget
+	repo ReadOnlyRepository
+}
+
+// NewGraph generates a new graph target of the repository with caching.
+func NewGraph(source ReadOnlyRepository, cache content.Storage) oras.ReadOnlyGraphTarget {
+	return &graphTarget{
+		referenceTarget: &referenceTarget{
+			target: &target{
+				ReadOnlyTarget: source,
+				cache:          cache,
+			},
+			ReferenceFetcher: source,
+		},
+		repo: source,
+	}
+}
+
+// Predecessors returns the nodes directly pointing to the current node.
+func (t *graphTarget) Predecessors(ctx context.Context, node ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	return t.repo.Predecessors(ctx, node)
+}
+
+// Referrers lists the descriptors of image or artifact manifests directly
+// referencing the given manifest descriptor.
+func (t *graphTarget) Referrers(ctx context.Context, desc ocispec.Descriptor, artifactType string, fn func(referrers []ocispec.Descriptor) error) error {
+	return t.repo.Referrers(ctx, desc, artifactType, fn)
+}
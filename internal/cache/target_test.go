@@ -270,3 +270,66 @@ func TestProxy_fetchReference(t *testing.T) {
 		t.Errorf("unexpected number of successful requests: %d, want %d", successCount, wantSuccessCount)
 	}
 }
+
+// testRepository is a repository backed by a memory store, counting the
+// fetches.
+type testRepository struct {
+	*memory.Store
+	fetched   atomic.Int64
+	referrers []ocispec.Descriptor
+}
+
+func (r *testRepository) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	r.fetched.Add(1)
+	return r.Store.Fetch(ctx, target)
+}
+
+func (r *testRepository) FetchReference(ctx context.Context, reference string) (ocispec.Descriptor, io.ReadCloser, error) {
+	desc, err := r.Resolve(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	rc, err := r.Fetch(ctx, desc)
+	return desc, rc, err
+}
+
+func (r *testRepository) Referrers(_ context.Context, _ ocispec.Descriptor, _ string, fn func(referrers []ocispec.Descriptor) error) error {
+	return fn(r.referrers)
+}
+
+func TestGraph(t *testing.T) {
+	blob := []byte("hello world")
+	desc := content.NewDescriptorFromBytes("test", blob)
+	referrer := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("referrer"))
+	repo := &testRepository{
+		Store:     memory.New(),
+		referrers: []ocispec.Descriptor{referrer},
+	}
+	ctx := context.Background()
+	if err := repo.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+
+	target := NewGraph(repo, memory.New())
+	for range 2 {
+		got, err := content.FetchAll(ctx, target, desc)
+		if err != nil {
+			t.Fatal("Fetch() error =", err)
+		}
+		if !bytes.Equal(got, blob) {
+			t.Errorf("Fetch() = %v, want %v", got, blob)
+		}
+	}
+	if got := repo.fetched.Load(); got != 1 {
+		t.Errorf("fetched from repository %d times, want 1", got)
+	}
+
+	subject := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("subject"))
+	referrers, err := registry.Referrers(ctx, target, subject, "")
+	if err != nil {
+		t.Fatal("Referrers() error =", err)
+	}
+	if !reflect.DeepEqual(referrers, repo.referrers) {
+		t.Errorf("Referrers() = %v, want %v", referrers, repo.referrers)
+	}
+}
@@ -0,0 +1,294 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chunk splits large files into multiple layers and reassembles them.
+package chunk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// Annotation keys of a chunk layer.
+const (
+	// AnnotationTitle is the annotation key for the title of the file that
+	// the chunk belongs to.
+	AnnotationTitle = "io.deis.oras.content.chunk.title"
+	// AnnotationIndex is the annotation key for the zero-based index of the
+	// chunk in the file.
+	AnnotationIndex = "io.deis.oras.content.chunk.index"
+	// AnnotationCount is the annotation key for the total number of chunks of
+	// the file.
+	AnnotationCount = "io.deis.oras.content.chunk.count"
+	// AnnotationDigest is the annotation key for the digest of the whole file.
+	AnnotationDigest = "io.deis.oras.content.chunk.digest"
+)
+
+var (
+	// ErrIncomplete is returned when some chunks of a file are missing.
+	ErrIncomplete = errors.New("incomplete chunks")
+	// ErrInvalidAnnotation is returned when a chunk has invalid annotations.
+	ErrInvalidAnnotation = errors.New("invalid chunk annotation")
+)
+
+// section is a section of a file.
+type section struct {
+	path   string
+	offset int64
+	size   int64
+}
+
+// Store is a read-only storage serving the chunks of local files.
+type Store struct {
+	lock     sync.RWMutex
+	sections map[digest.Digest]section
+}
+
+// NewStore creates a new chunk store.
+func NewStore() *Store {
+	return &Store{
+		sections: make(map[digest.Digest]section),
+	}
+}
+
+// Add splits the file at path into chunks of chunkSize bytes and returns the
+// descriptors of the chunks in order. Each chunk is annotated with name, its
+// index, the total count of chunks and the digest of the whole file.
+func (s *Store) Add(path string, name string, mediaType string, chunkSize int64) ([]ocispec.Descriptor, error) {
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("invalid chunk size %d", chunkSize)
+	}
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = fp.Close() }()
+	fi, err := fp.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s: only regular files can be chunked", path)
+	}
+
+	count := (fi.Size() + chunkSize - 1) / chunkSize
+	fileDigester := digest.Canonical.Digester()
+	descs := make([]ocispec.Descriptor, 0, count)
+	sections := make([]section, 0, count)
+	for offset := int64(0); offset < fi.Size(); offset += chunkSize {
+		size := min(chunkSize, fi.Size()-offset)
+		chunkDigester := digest.Canonical.Digester()
+		w := io.MultiWriter(fileDigester.Hash(), chunkDigester.Hash())
+		if _, err := io.CopyN(w, fp, size); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		descs = append(descs, ocispec.Descriptor{
+			MediaType: mediaType,
+			Digest:    chunkDigester.Digest(),
+			Size:      size,
+			Annotations: map[string]string{
+				AnnotationTitle: name,
+				AnnotationIndex: strconv.Itoa(len(descs)),
+				AnnotationCount: strconv.FormatInt(count, 10),
+			},
+		})
+		sections = append(sections, section{
+			path:   path,
+			offset: offset,
+			size:   size,
+		})
+	}
+
+	fileDigest := fileDigester.Digest().String()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i := range descs {
+		descs[i].Annotations[AnnotationDigest] = fileDigest
+		s.sections[descs[i].Digest] = sections[i]
+	}
+	return descs, nil
+}
+
+// Fetch fetches the chunk identified by the descriptor.
+func (s *Store) Fetch(_ context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	s.lock.RLock()
+	sec, ok := s.sections[target.Digest]
+	s.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", target.Digest, errdef.ErrNotFound)
+	}
+	fp, err := os.Open(sec.path)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.NewSectionReader(fp, sec.offset, sec.size),
+		Closer: fp,
+	}, nil
+}
+
+// Exists returns true if the described chunk exists.
+func (s *Store) Exists(_ context.Context, target ocispec.Descriptor) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.sections[target.Digest]
+	return ok, nil
+}
+
+// Resolve always returns ErrNotFound since chunks are not tagged.
+func (s *Store) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	return ocispec.Descriptor{}, fmt.Errorf("%s: %w", reference, errdef.ErrNotFound)
+}
+
+// IsChunk returns true if the descriptor is a chunk of a file.
+func IsChunk(desc ocispec.Descriptor) bool {
+	_, ok := desc.Annotations[AnnotationTitle]
+	return ok
+}
+
+// File describes a file assembled from chunks.
+type File struct {
+	// Descriptor describes the whole file, titled with the original file name.
+	Descriptor ocispec.Descriptor
+	// Chunks are the chunks of the file in order.
+	Chunks []ocispec.Descriptor
+}
+
+// Group groups chunks by the files they belong to. The returned files are
+// validated to be complete.
+func Group(chunks []ocispec.Descriptor) ([]File, error) {
+	type key struct {
+		name   string
+		digest string
+	}
+	var keys []key
+	groups := make(map[key][]ocispec.Descriptor)
+	for _, c := range chunks {
+		k := key{
+			name:   c.Annotations[AnnotationTitle],
+			digest: c.Annotations[AnnotationDigest],
+		}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], c)
+	}
+
+	files := make([]File, 0, len(keys))
+	for _, k := range keys {
+		file, err := newFile(k.name, k.digest, groups[k])
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func newFile(name string, fileDigest string, chunks []ocispec.Descriptor) (File, error) {
+	dgst, err := digest.Parse(fileDigest)
+	if name == "" || err != nil {
+		return File{}, fmt.Errorf("%w: file %q with digest %q", ErrInvalidAnnotation, name, fileDigest)
+	}
+	count, err := strconv.Atoi(chunks[0].Annotations[AnnotationCount])
+	if err != nil || count <= 0 {
+		return File{}, fmt.Errorf("%w: %s: invalid chunk count %q", ErrInvalidAnnotation, name, chunks[0].Annotations[AnnotationCount])
+	}
+	ordered := make([]ocispec.Descriptor, count)
+	var size int64
+	for _, c := range chunks {
+		index, err := strconv.Atoi(c.Annotations[AnnotationIndex])
+		if err != nil || index < 0 || index >= count || c.Annotations[AnnotationCount] != chunks[0].Annotations[AnnotationCount] {
+			return File{}, fmt.Errorf("%w: %s: invalid chunk index %q", ErrInvalidAnnotation, name, c.Annotations[AnnotationIndex])
+		}
+		if ordered[index].Digest != "" {
+			if content.Equal(ordered[index], c) {
+				// the same chunk is referenced more than once
+				continue
+			}
+			return File{}, fmt.Errorf("%w: %s: duplicated chunk index %d", ErrInvalidAnnotation, name, index)
+		}
+		ordered[index] = c
+		size += c.Size
+	}
+	if i := slices.IndexFunc(ordered, func(desc ocispec.Descriptor) bool { return desc.Digest == "" }); i >= 0 {
+		return File{}, fmt.Errorf("%w: %s: missing chunk %d of %d", ErrIncomplete, name, i, count)
+	}
+	return File{
+		Descriptor: ocispec.Descriptor{
+			MediaType: ordered[0].MediaType,
+			Digest:    dgst,
+			Size:      size,
+			Annotations: map[string]string{
+				ocispec.AnnotationTitle: name,
+			},
+		},
+		Chunks: ordered,
+	}, nil
+}
+
+// Assemble fetches the chunks of the file in order and writes them to w. Each
+// chunk and the whole file are verified against their digests. onChunk, if
+// not nil, is called before and after each chunk is fetched.
+func Assemble(ctx context.Context, fetcher content.Fetcher, file File, w io.Writer, onChunk func(chunk ocispec.Descriptor, done bool) error) error {
+	verifier := file.Descriptor.Digest.Verifier()
+	w = io.MultiWriter(w, verifier)
+	for _, c := range file.Chunks {
+		if onChunk != nil {
+			if err := onChunk(c, false); err != nil {
+				return err
+			}
+		}
+		if err := copyChunk(ctx, fetcher, c, w); err != nil {
+			return err
+		}
+		if onChunk != nil {
+			if err := onChunk(c, true); err != nil {
+				return err
+			}
+		}
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("%s: %w", file.Descriptor.Annotations[ocispec.AnnotationTitle], content.ErrMismatchedDigest)
+	}
+	return nil
+}
+
+func copyChunk(ctx context.Context, fetcher content.Fetcher, chunk ocispec.Descriptor, w io.Writer) error {
+	rc, err := fetcher.Fetch(ctx, chunk)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+	vr := content.NewVerifyReader(rc, chunk)
+	if _, err := io.Copy(w, vr); err != nil {
+		return err
+	}
+	return vr.Verify()
+}
@@ -0,0 +1,169 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chunk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+const mediaType = "application/vnd.test"
+
+func prepareFile(t *testing.T, content []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "model.bin")
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestStore_Add(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("hello world!")
+	path := prepareFile(t, blob)
+	s := NewStore()
+
+	descs, err := s.Add(path, "model.bin", mediaType, 5)
+	if err != nil {
+		t.Fatal("Store.Add() error =", err)
+	}
+	wantParts := [][]byte{blob[:5], blob[5:10], blob[10:]}
+	if len(descs) != len(wantParts) {
+		t.Fatalf("Store.Add() returned %d chunks, want %d", len(descs), len(wantParts))
+	}
+	for i, desc := range descs {
+		if desc.Digest != digest.FromBytes(wantParts[i]) || desc.Size != int64(len(wantParts[i])) {
+			t.Errorf("chunk %d = %v, want content %q", i, desc, wantParts[i])
+		}
+		if desc.Annotations[AnnotationDigest] != digest.FromBytes(blob).String() {
+			t.Errorf("chunk %d file digest = %s, want %s", i, desc.Annotations[AnnotationDigest], digest.FromBytes(blob))
+		}
+		if desc.Annotations[AnnotationCount] != "3" || desc.Annotations[AnnotationTitle] != "model.bin" {
+			t.Errorf("chunk %d annotations = %v", i, desc.Annotations)
+		}
+		if !IsChunk(desc) {
+			t.Errorf("IsChunk(chunk %d) = false, want true", i)
+		}
+		got, err := content.FetchAll(ctx, s, desc)
+		if err != nil {
+			t.Fatalf("Store.Fetch(chunk %d) error = %v", i, err)
+		}
+		if !bytes.Equal(got, wantParts[i]) {
+			t.Errorf("Store.Fetch(chunk %d) = %q, want %q", i, got, wantParts[i])
+		}
+	}
+
+	if _, err := s.Fetch(ctx, ocispec.Descriptor{Digest: digest.FromString("foo")}); !errors.Is(err, errdef.ErrNotFound) {
+		t.Errorf("Store.Fetch() error = %v, want %v", err, errdef.ErrNotFound)
+	}
+	if _, err := s.Add(path, "model.bin", mediaType, 0); err == nil {
+		t.Error("Store.Add() with zero chunk size error = nil, want error")
+	}
+	if _, err := s.Add(filepath.Dir(path), "model.bin", mediaType, 5); err == nil {
+		t.Error("Store.Add() with directory error = nil, want error")
+	}
+}
+
+func TestGroup_and_Assemble(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("hello world!")
+	s := NewStore()
+	descs, err := s.Add(prepareFile(t, blob), "model.bin", mediaType, 5)
+	if err != nil {
+		t.Fatal("Store.Add() error =", err)
+	}
+
+	// shuffled and duplicated chunks
+	shuffled := []ocispec.Descriptor{descs[2], descs[0], descs[1], descs[0]}
+	files, err := Group(shuffled)
+	if err != nil {
+		t.Fatal("Group() error =", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Group() returned %d files, want 1", len(files))
+	}
+	f := files[0]
+	if !slices.EqualFunc(f.Chunks, descs, content.Equal) {
+		t.Errorf("Group() chunks = %v, want %v", f.Chunks, descs)
+	}
+	if f.Descriptor.Digest != digest.FromBytes(blob) || f.Descriptor.Size != int64(len(blob)) || f.Descriptor.Annotations[ocispec.AnnotationTitle] != "model.bin" {
+		t.Errorf("Group() descriptor = %v", f.Descriptor)
+	}
+
+	var buf bytes.Buffer
+	var events int
+	onChunk := func(ocispec.Descriptor, bool) error {
+		events++
+		return nil
+	}
+	if err := Assemble(ctx, s, f, &buf, onChunk); err != nil {
+		t.Fatal("Assemble() error =", err)
+	}
+	if !bytes.Equal(buf.Bytes(), blob) {
+		t.Errorf("Assemble() = %q, want %q", buf.Bytes(), blob)
+	}
+	if events != 2*len(descs) {
+		t.Errorf("Assemble() reported %d chunk events, want %d", events, 2*len(descs))
+	}
+
+	// mismatched file digest
+	f.Descriptor.Digest = digest.FromString("foo")
+	if err := Assemble(ctx, s, f, &bytes.Buffer{}, nil); !errors.Is(err, content.ErrMismatchedDigest) {
+		t.Errorf("Assemble() error = %v, want %v", err, content.ErrMismatchedDigest)
+	}
+}
+
+func TestGroup_invalid(t *testing.T) {
+	s := NewStore()
+	descs, err := s.Add(prepareFile(t, []byte("hello world!")), "model.bin", mediaType, 5)
+	if err != nil {
+		t.Fatal("Store.Add() error =", err)
+	}
+	if _, err := Group(descs[:2]); !errors.Is(err, ErrIncomplete) {
+		t.Errorf("Group() with missing chunk error = %v, want %v", err, ErrIncomplete)
+	}
+
+	invalid := descs[0]
+	invalid.Annotations = map[string]string{
+		AnnotationTitle:  "model.bin",
+		AnnotationDigest: "invalid",
+	}
+	if _, err := Group([]ocispec.Descriptor{invalid}); !errors.Is(err, ErrInvalidAnnotation) {
+		t.Errorf("Group() with invalid digest error = %v, want %v", err, ErrInvalidAnnotation)
+	}
+
+	outOfRange := descs[0]
+	outOfRange.Annotations = map[string]string{
+		AnnotationTitle:  "model.bin",
+		AnnotationDigest: descs[0].Annotations[AnnotationDigest],
+		AnnotationCount:  "1",
+		AnnotationIndex:  "1",
+	}
+	if _, err := Group([]ocispec.Descriptor{outOfRange}); !errors.Is(err, ErrInvalidAnnotation) {
+		t.Errorf("Group() with invalid index error = %v, want %v", err, ErrInvalidAnnotation)
+	}
+}
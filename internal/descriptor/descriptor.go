@@ -16,6 +16,8 @@ limitations under the License.
 package descriptor
 
 import (
+	"time"
+
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/internal/docker"
@@ -80,3 +82,31 @@ func GetTitleOrMediaType(desc ocispec.Descriptor) (name string, isTitle bool) {
 func GenerateContentKey(desc ocispec.Descriptor) string {
 	return desc.Digest.String() + desc.Annotations[ocispec.AnnotationTitle]
 }
+
+// PlatformString returns the platform of the descriptor in the form of
+// os/arch[/variant], or an empty string if the platform is not specified.
+func PlatformString(desc ocispec.Descriptor) string {
+	if desc.Platform == nil {
+		return ""
+	}
+	platform := desc.Platform.OS + "/" + desc.Platform.Architecture
+	if desc.Platform.Variant != "" {
+		platform += "/" + desc.Platform.Variant
+	}
+	return platform
+}
+
+// CreatedTime returns the creation time of the descriptor according to the
+// annotation "org.opencontainers.image.created" in RFC 3339 format. ok is
+// false if the annotation is missing or invalid.
+func CreatedTime(desc ocispec.Descriptor) (created time.Time, ok bool) {
+	value, ok := desc.Annotations[ocispec.AnnotationCreated]
+	if !ok {
+		return time.Time{}, false
+	}
+	created, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return created, true
+}
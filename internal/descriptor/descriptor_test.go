@@ -18,6 +18,7 @@ package descriptor_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/internal/descriptor"
@@ -103,3 +104,37 @@ func TestDescriptor_GenerateContentKey(t *testing.T) {
 		t.Fatalf("GenerateContentKey got %v, want %v", got, expected)
 	}
 }
+
+func TestDescriptor_PlatformString(t *testing.T) {
+	tests := []struct {
+		platform *ocispec.Platform
+		want     string
+	}{
+		{nil, ""},
+		{&ocispec.Platform{OS: "linux", Architecture: "amd64"}, "linux/amd64"},
+		{&ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, "linux/arm/v7"},
+	}
+	for _, tt := range tests {
+		if got := descriptor.PlatformString(ocispec.Descriptor{Platform: tt.platform}); got != tt.want {
+			t.Errorf("PlatformString() got %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestDescriptor_CreatedTime(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		want        time.Time
+		wantOK      bool
+	}{
+		{nil, time.Time{}, false},
+		{map[string]string{ocispec.AnnotationCreated: "yesterday"}, time.Time{}, false},
+		{map[string]string{ocispec.AnnotationCreated: "2023-01-18T08:37:42Z"}, time.Date(2023, 1, 18, 8, 37, 42, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		got, ok := descriptor.CreatedTime(ocispec.Descriptor{Annotations: tt.annotations})
+		if !got.Equal(tt.want) || ok != tt.wantOK {
+			t.Errorf("CreatedTime() got %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// AnnotationMode is the annotation key for the permission bits of a file
+	// in octal.
+	AnnotationMode = "io.deis.oras.content.mode"
+	// AnnotationModTime is the annotation key for the modification time of a
+	// file in RFC 3339 format.
+	AnnotationModTime = "io.deis.oras.content.mtime"
+	// SafeModeMask masks the mode bits applied to pulled files, so that the
+	// setuid, setgid and sticky bits are never set.
+	SafeModeMask fs.FileMode = fs.ModePerm
+)
+
+// ModeAnnotations returns the annotations recording the permission bits and
+// the modification time of a file.
+func ModeAnnotations(fi fs.FileInfo) map[string]string {
+	return map[string]string{
+		AnnotationMode:    fmt.Sprintf("%#o", fi.Mode().Perm()),
+		AnnotationModTime: fi.ModTime().UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// ApplyMode applies the permission bits and the modification time recorded
+// in the annotations to the file at path. The permission bits are masked by
+// SafeModeMask. Missing annotations are ignored.
+func ApplyMode(path string, annotations map[string]string) error {
+	if value, ok := annotations[AnnotationMode]; ok {
+		mode, err := strconv.ParseUint(value, 8, 32)
+		if err != nil {
+			return fmt.Errorf("invalid file mode %q of %s: %w", value, path, err)
+		}
+		if err := os.Chmod(path, fs.FileMode(mode)&SafeModeMask); err != nil {
+			return err
+		}
+	}
+	if value, ok := annotations[AnnotationModTime]; ok {
+		mtime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return fmt.Errorf("invalid modification time %q of %s: %w", value, path, err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SanitizeModes removes the mode bits not in SafeModeMask from the files and
+// directories under root, such as the ones extracted from a tarball.
+func SanitizeModes(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.Type()&fs.ModeSymlink != 0 {
+			return err
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		mode := fi.Mode()
+		if mode&(fs.ModeSetuid|fs.ModeSetgid|fs.ModeSticky) == 0 {
+			return nil
+		}
+		return os.Chmod(path, mode&SafeModeMask)
+	})
+}
+
+// ApplyTarModes applies the permission bits in the headers of the gzipped
+// tarball read from r to the regular files and the directories extracted from
+// it into dir, where name is the path prefix of the entries in the tarball.
+// The permission bits are masked by SafeModeMask. Entries outside of name are
+// ignored.
+func ApplyTarModes(r io.Reader, dir string, name string) error {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = zr.Close() }()
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeDir {
+			continue
+		}
+		rel, err := filepath.Rel(name, filepath.FromSlash(header.Name))
+		if err != nil || !filepath.IsLocal(rel) {
+			continue
+		}
+		if err := os.Chmod(filepath.Join(dir, rel), fs.FileMode(header.Mode)&SafeModeMask); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"oras.land/oras/internal/file"
+)
+
+func TestApplyMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not supported on Windows")
+	}
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	writeFiles(t, dir, map[string]string{"src": "src", "dst": "dst"})
+	if err := os.Chmod(src, 0750|fs.ModeSetuid); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := os.Chtimes(src, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations := file.ModeAnnotations(fi)
+	if want := "0750"; annotations[file.AnnotationMode] != want {
+		t.Errorf("ModeAnnotations() mode = %q, want %q", annotations[file.AnnotationMode], want)
+	}
+
+	dst := filepath.Join(dir, "dst")
+	if err := file.ApplyMode(dst, annotations); err != nil {
+		t.Fatal("ApplyMode() error =", err)
+	}
+	fi, err = os.Stat(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fi.Mode(); got != 0750 {
+		t.Errorf("mode = %v, want %v", got, fs.FileMode(0750))
+	}
+	if got := fi.ModTime(); !got.Equal(mtime) {
+		t.Errorf("modification time = %v, want %v", got, mtime)
+	}
+
+	// setuid bits are never applied
+	if err := file.ApplyMode(dst, map[string]string{file.AnnotationMode: "4755"}); err != nil {
+		t.Fatal("ApplyMode() error =", err)
+	}
+	if fi, err = os.Stat(dst); err != nil {
+		t.Fatal(err)
+	}
+	if got := fi.Mode(); got != 0755 {
+		t.Errorf("mode = %v, want %v", got, fs.FileMode(0755))
+	}
+}
+
+func TestApplyMode_invalid(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"dst": "dst"})
+	dst := filepath.Join(dir, "dst")
+	for _, annotations := range []map[string]string{
+		{file.AnnotationMode: "rwxr-xr-x"},
+		{file.AnnotationModTime: "yesterday"},
+	} {
+		if err := file.ApplyMode(dst, annotations); err == nil {
+			t.Errorf("ApplyMode(%v) error = nil, want error", annotations)
+		}
+	}
+	if err := file.ApplyMode(dst, nil); err != nil {
+		t.Error("ApplyMode() error =", err)
+	}
+}
+
+func TestSanitizeModes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not supported on Windows")
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a": "a", "sub/b": "b"})
+	modes := map[string]fs.FileMode{
+		"a":     0755 | fs.ModeSetuid,
+		"sub":   0775 | fs.ModeSetgid | fs.ModeSticky,
+		"sub/b": 0640,
+	}
+	for name, mode := range modes {
+		if err := os.Chmod(filepath.Join(dir, name), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := file.SanitizeModes(dir); err != nil {
+		t.Fatal("SanitizeModes() error =", err)
+	}
+	for name, mode := range modes {
+		fi, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := fi.Mode()&^fs.ModeDir, mode&fs.ModePerm; got != want {
+			t.Errorf("mode of %s = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestApplyTarModes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not supported on Windows")
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a": "a", "sub/b": "b", "c": "c"})
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	headers := []*tar.Header{
+		{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0750},
+		{Name: "dir/a", Typeflag: tar.TypeReg, Mode: 04777},
+		{Name: "dir/sub/", Typeflag: tar.TypeDir, Mode: 0700},
+		{Name: "dir/sub/b", Typeflag: tar.TypeReg, Mode: 0640},
+		{Name: "other/c", Typeflag: tar.TypeReg, Mode: 0600},
+		{Name: "dir/../c", Typeflag: tar.TypeReg, Mode: 0600},
+	}
+	for _, h := range headers {
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(filepath.Join(dir, "c"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := file.ApplyTarModes(&buf, dir, "dir"); err != nil {
+		t.Fatal("ApplyTarModes() error =", err)
+	}
+	want := map[string]fs.FileMode{
+		".":     0750,
+		"a":     0777,
+		"sub":   0700,
+		"sub/b": 0640,
+		"c":     0644,
+	}
+	for name, mode := range want {
+		fi, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fi.Mode().Perm(); got != mode {
+			t.Errorf("mode of %s = %v, want %v", name, got, mode)
+		}
+	}
+	if err := os.Chmod(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+}
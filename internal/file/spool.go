@@ -0,0 +1,125 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// Spool is a read-only storage for content that can only be read once, such
+// as stdin. Content added to the spool is written to temporary files, with the
+// digest and size computed on the fly.
+type Spool struct {
+	lock  sync.RWMutex
+	paths map[digest.Digest]string
+}
+
+// NewSpool creates a new spool.
+func NewSpool() *Spool {
+	return &Spool{
+		paths: make(map[digest.Digest]string),
+	}
+}
+
+// Add spools the content read from r into a temporary file and returns the
+// descriptor of the content, titled with name.
+func (s *Spool) Add(r io.Reader, mediaType string, name string) (desc ocispec.Descriptor, err error) {
+	fp, err := os.CreateTemp("", "oras_spool_*")
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to create spool file: %w", err)
+	}
+	defer func() {
+		closeErr := fp.Close()
+		if err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(fp.Name())
+		}
+	}()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(fp, io.TeeReader(r, digester.Hash()))
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to spool %s: %w", name, err)
+	}
+	desc = ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digester.Digest(),
+		Size:      size,
+	}
+	if name != "" {
+		desc.Annotations = map[string]string{
+			ocispec.AnnotationTitle: name,
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if path, ok := s.paths[desc.Digest]; ok {
+		// identical content has been spooled before
+		_ = os.Remove(path)
+	}
+	s.paths[desc.Digest] = fp.Name()
+	return desc, nil
+}
+
+// Fetch fetches the spooled content identified by the descriptor.
+func (s *Spool) Fetch(_ context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	s.lock.RLock()
+	path, ok := s.paths[target.Digest]
+	s.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", target.Digest, errdef.ErrNotFound)
+	}
+	return os.Open(path)
+}
+
+// Exists returns true if the described content has been spooled.
+func (s *Spool) Exists(_ context.Context, target ocispec.Descriptor) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.paths[target.Digest]
+	return ok, nil
+}
+
+// Resolve always returns ErrNotFound since spooled content is not tagged.
+func (s *Spool) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	return ocispec.Descriptor{}, fmt.Errorf("%s: %w", reference, errdef.ErrNotFound)
+}
+
+// Close removes all the spooled files.
+func (s *Spool) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var errs []error
+	for dgst, path := range s.paths {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+		delete(s.paths, dgst)
+	}
+	return errors.Join(errs...)
+}
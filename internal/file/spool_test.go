@@ -0,0 +1,105 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras/internal/file"
+)
+
+func TestSpool_Add(t *testing.T) {
+	ctx := context.Background()
+	content := "hello world!"
+	spool := file.NewSpool()
+	defer func() { _ = spool.Close() }()
+
+	got, err := spool.Add(strings.NewReader(content), blobMediaType, "hello.txt")
+	if err != nil {
+		t.Fatal("Spool.Add() error =", err)
+	}
+	want := ocispec.Descriptor{
+		MediaType: blobMediaType,
+		Digest:    digest.FromString(content),
+		Size:      int64(len(content)),
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: "hello.txt",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Spool.Add() = %v, want %v", got, want)
+	}
+
+	exists, err := spool.Exists(ctx, got)
+	if err != nil || !exists {
+		t.Fatalf("Spool.Exists() = %v, %v, want true", exists, err)
+	}
+	rc, err := spool.Fetch(ctx, got)
+	if err != nil {
+		t.Fatal("Spool.Fetch() error =", err)
+	}
+	fetched, err := io.ReadAll(rc)
+	_ = rc.Close()
+	if err != nil {
+		t.Fatal("failed to read fetched content:", err)
+	}
+	if string(fetched) != content {
+		t.Fatalf("Spool.Fetch() = %q, want %q", fetched, content)
+	}
+}
+
+func TestSpool_Fetch_notFound(t *testing.T) {
+	spool := file.NewSpool()
+	defer func() { _ = spool.Close() }()
+	desc := ocispec.Descriptor{
+		MediaType: blobMediaType,
+		Digest:    digest.FromString("foo"),
+		Size:      3,
+	}
+	if _, err := spool.Fetch(context.Background(), desc); !errors.Is(err, errdef.ErrNotFound) {
+		t.Fatalf("Spool.Fetch() error = %v, want %v", err, errdef.ErrNotFound)
+	}
+	if _, err := spool.Resolve(context.Background(), "foo"); !errors.Is(err, errdef.ErrNotFound) {
+		t.Fatalf("Spool.Resolve() error = %v, want %v", err, errdef.ErrNotFound)
+	}
+}
+
+func TestSpool_Close(t *testing.T) {
+	ctx := context.Background()
+	spool := file.NewSpool()
+	desc, err := spool.Add(strings.NewReader("foo"), blobMediaType, "")
+	if err != nil {
+		t.Fatal("Spool.Add() error =", err)
+	}
+	if desc.Annotations != nil {
+		t.Fatalf("Spool.Add() annotations = %v, want nil", desc.Annotations)
+	}
+	if err := spool.Close(); err != nil {
+		t.Fatal("Spool.Close() error =", err)
+	}
+	exists, err := spool.Exists(ctx, desc)
+	if err != nil || exists {
+		t.Fatalf("Spool.Exists() = %v, %v, want false", exists, err)
+	}
+}
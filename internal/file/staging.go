@@ -0,0 +1,227 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ErrReplaceWorkingDir is returned when replacing the current working
+// directory or any of its parents.
+var ErrReplaceWorkingDir = errors.New("cannot replace the current working directory or its parents")
+
+// ErrReplaceUnsupported is returned when a directory cannot be replaced
+// atomically since the platform or the file system does not support
+// exchanging two paths with a single rename.
+var ErrReplaceUnsupported = errors.New("atomic replacement of directories is not supported on this platform or file system")
+
+// Staging is a staging directory, where files are prepared before being
+// moved into the target directory.
+type Staging struct {
+	// Dir is the path of the staging directory, which is a sibling of the
+	// target directory so that files can be renamed into it, or a hidden
+	// directory in the target directory if the parent is not writable. In the
+	// latter case, the staging directory is visible in the target directory
+	// until it is committed or discarded.
+	Dir    string
+	target string
+}
+
+// NewStaging creates a staging directory for the target directory. If replace
+// is true, the staging directory is to replace the target directory as a
+// whole, and so the target directory must be replaceable and the file system
+// must support replacing it atomically, or ErrReplaceUnsupported is returned.
+func NewStaging(target string, replace bool) (*Staging, error) {
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return nil, err
+	}
+	if replace {
+		if err := CheckReplaceable(target); err != nil {
+			return nil, err
+		}
+	}
+	parent := filepath.Dir(target)
+	if err := os.MkdirAll(parent, 0777); err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp(parent, "."+filepath.Base(target)+".staging-*")
+	if err != nil && errors.Is(err, fs.ErrPermission) && !replace {
+		// files can also be renamed from a directory in the target directory
+		if fi, statErr := os.Stat(target); statErr == nil && fi.IsDir() {
+			dir, err = os.MkdirTemp(target, ".oras.staging-*")
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	if _, statErr := os.Lstat(target); replace && statErr == nil {
+		if err := checkExchange(dir); err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, err
+		}
+	}
+	mode := fs.FileMode(0755)
+	if fi, err := os.Stat(target); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	if err := os.Chmod(dir, mode); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return &Staging{
+		Dir:    dir,
+		target: target,
+	}, nil
+}
+
+// CheckReplaceable returns ErrReplaceWorkingDir if the target directory is
+// the current working directory or any of its parents.
+func CheckReplaceable(target string) error {
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if resolved, err := filepath.EvalSymlinks(target); err == nil {
+		target = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(wd); err == nil {
+		wd = resolved
+	}
+	if rel, err := filepath.Rel(target, wd); err == nil && filepath.IsLocal(rel) {
+		return fmt.Errorf("%s: %w", target, ErrReplaceWorkingDir)
+	}
+	return nil
+}
+
+// checkExchange checks if the empty directory dir can be exchanged with a
+// sibling atomically, so that the lack of support is reported before any file
+// is staged.
+func checkExchange(dir string) error {
+	probe, err := os.MkdirTemp(filepath.Dir(dir), filepath.Base(dir)+".probe-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(probe) }()
+	// both directories are empty and so exchanging them changes nothing
+	return exchange(dir, probe)
+}
+
+// Commit moves the staged files into the target directory, replacing the
+// existing files of the same paths. If noOverwrite is true, Commit fails
+// without moving any file if any staged file exists in the target directory.
+//
+// Each file is moved with a single rename, but the files are not moved as a
+// whole atomically: if Commit fails midway, the target directory is left with
+// part of the staged files moved in and the rest remaining staged.
+func (s *Staging) Commit(noOverwrite bool) error {
+	if noOverwrite {
+		if err := checkConflicts(s.Dir, s.target); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(s.target, 0777); err != nil {
+		return err
+	}
+	if err := moveTree(s.Dir, s.target); err != nil {
+		return err
+	}
+	return os.RemoveAll(s.Dir)
+}
+
+// Replace atomically replaces the target directory with the staging directory
+// as a whole, by a single rename if the target directory does not exist, or
+// by exchanging the two directories otherwise. ErrReplaceUnsupported is
+// returned if the exchange is not supported, leaving the target directory
+// untouched.
+func (s *Staging) Replace() error {
+	if err := CheckReplaceable(s.target); err != nil {
+		return err
+	}
+	if _, err := os.Lstat(s.target); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return os.Rename(s.Dir, s.target)
+	}
+	if err := exchange(s.Dir, s.target); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", s.target, err)
+	}
+	// the staging directory holds the old files after the exchange
+	return os.RemoveAll(s.Dir)
+}
+
+// Discard removes the staging directory if it is not committed.
+func (s *Staging) Discard() error {
+	return os.RemoveAll(s.Dir)
+}
+
+// checkConflicts returns an error if any file in src exists in dst.
+func checkConflicts(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Lstat(filepath.Join(dst, rel)); err == nil {
+			return fmt.Errorf("%s: %w", filepath.Join(dst, rel), fs.ErrExist)
+		}
+		return nil
+	})
+}
+
+// moveTree moves the files in src into dst, merging the directories.
+func moveTree(src, dst string) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		srcPath := filepath.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+		fi, err := os.Lstat(dstPath)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return err
+		case entry.IsDir() && fi.IsDir():
+			if err := moveTree(srcPath, dstPath); err != nil {
+				return err
+			}
+			continue
+		case entry.IsDir() || fi.IsDir():
+			// files are replaced by renaming, others are removed first
+			if err := os.RemoveAll(dstPath); err != nil {
+				return err
+			}
+		}
+		if err := os.Rename(srcPath, dstPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
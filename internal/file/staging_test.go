@@ -0,0 +1,237 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"oras.land/oras/internal/file"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func checkFiles(t *testing.T, root string, want map[string]string) {
+	t.Helper()
+	got := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		got[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("files = %v, want %v", got, want)
+	}
+	for name, content := range want {
+		if got[name] != content {
+			t.Errorf("file %s = %q, want %q", name, got[name], content)
+		}
+	}
+}
+
+func TestStaging_Commit(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "out")
+	writeFiles(t, target, map[string]string{
+		"keep.txt":      "old",
+		"bin/app":       "old",
+		"bin/lib/a.so":  "old",
+		"docs":          "a file to be replaced by a directory",
+		"conf/settings": "a directory to be replaced by a file",
+	})
+	staging, err := file.NewStaging(target, false)
+	if err != nil {
+		t.Fatal("NewStaging() error =", err)
+	}
+	if filepath.Dir(staging.Dir) != filepath.Dir(target) {
+		t.Errorf("staging directory %s is not a sibling of %s", staging.Dir, target)
+	}
+	writeFiles(t, staging.Dir, map[string]string{
+		"bin/app":      "new",
+		"bin/lib/b.so": "new",
+		"docs/a.md":    "new",
+		"conf":         "new",
+	})
+	if err := staging.Commit(true); !errors.Is(err, fs.ErrExist) {
+		t.Fatalf("Staging.Commit() error = %v, want %v", err, fs.ErrExist)
+	}
+	if err := staging.Commit(false); err != nil {
+		t.Fatal("Staging.Commit() error =", err)
+	}
+	checkFiles(t, target, map[string]string{
+		"keep.txt":     "old",
+		"bin/app":      "new",
+		"bin/lib/a.so": "old",
+		"bin/lib/b.so": "new",
+		"docs/a.md":    "new",
+		"conf":         "new",
+	})
+	if _, err := os.Stat(staging.Dir); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("staging directory not removed: %v", err)
+	}
+}
+
+func TestStaging_Replace(t *testing.T) {
+	parent := t.TempDir()
+	target := filepath.Join(parent, "out")
+	writeFiles(t, target, map[string]string{"old.txt": "old"})
+	staging, err := file.NewStaging(target, true)
+	if errors.Is(err, file.ErrReplaceUnsupported) {
+		t.Skip("atomic replacement is not supported:", err)
+	}
+	if err != nil {
+		t.Fatal("NewStaging() error =", err)
+	}
+	writeFiles(t, staging.Dir, map[string]string{"new.txt": "new"})
+	if err := staging.Replace(); err != nil {
+		t.Fatal("Staging.Replace() error =", err)
+	}
+	checkFiles(t, target, map[string]string{"new.txt": "new"})
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("entries of parent directory = %v, want only the target directory", entries)
+	}
+}
+
+func TestStaging_Replace_newTarget(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "a", "out")
+	staging, err := file.NewStaging(target, true)
+	if err != nil {
+		t.Fatal("NewStaging() error =", err)
+	}
+	writeFiles(t, staging.Dir, map[string]string{"new.txt": "new"})
+	if err := staging.Replace(); err != nil {
+		t.Fatal("Staging.Replace() error =", err)
+	}
+	checkFiles(t, target, map[string]string{"new.txt": "new"})
+}
+
+func TestStaging_Discard(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "out")
+	writeFiles(t, target, map[string]string{"old.txt": "old"})
+	staging, err := file.NewStaging(target, false)
+	if err != nil {
+		t.Fatal("NewStaging() error =", err)
+	}
+	writeFiles(t, staging.Dir, map[string]string{"new.txt": "new"})
+	if err := staging.Discard(); err != nil {
+		t.Fatal("Staging.Discard() error =", err)
+	}
+	checkFiles(t, target, map[string]string{"old.txt": "old"})
+	if _, err := os.Stat(staging.Dir); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("staging directory not removed: %v", err)
+	}
+}
+
+func TestNewStaging_readOnlyParent(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced for root")
+	}
+	parent := t.TempDir()
+	target := filepath.Join(parent, "out")
+	writeFiles(t, target, map[string]string{"old.txt": "old"})
+	if err := os.Chmod(parent, 0555); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(parent, 0755)
+
+	if _, err := file.NewStaging(target, true); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("NewStaging() error = %v, want %v", err, fs.ErrPermission)
+	}
+	staging, err := file.NewStaging(target, false)
+	if err != nil {
+		t.Fatal("NewStaging() error =", err)
+	}
+	if filepath.Dir(staging.Dir) != target {
+		t.Errorf("staging directory %s is not in %s", staging.Dir, target)
+	}
+	writeFiles(t, staging.Dir, map[string]string{"new.txt": "new"})
+	if err := staging.Commit(false); err != nil {
+		t.Fatal("Staging.Commit() error =", err)
+	}
+	checkFiles(t, target, map[string]string{"old.txt": "old", "new.txt": "new"})
+}
+
+func TestCheckReplaceable(t *testing.T) {
+	root := t.TempDir()
+	wd := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(wd, 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(wd, filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(wd)
+	tests := []struct {
+		name    string
+		target  string
+		wantErr bool
+	}{
+		{"working directory", ".", true},
+		{"absolute working directory", wd, true},
+		{"parent", "..", true},
+		{"root", string(filepath.Separator), true},
+		{"symbolic link", filepath.Join(root, "link"), true},
+		{"subdirectory", "out", false},
+		{"sibling", filepath.Join("..", "c"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := file.CheckReplaceable(tt.target); errors.Is(err, file.ErrReplaceWorkingDir) != tt.wantErr {
+				t.Errorf("CheckReplaceable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStaging_Replace_workingDir(t *testing.T) {
+	target := t.TempDir()
+	writeFiles(t, target, map[string]string{"old.txt": "old"})
+	t.Chdir(target)
+	if _, err := file.NewStaging(".", true); !errors.Is(err, file.ErrReplaceWorkingDir) {
+		t.Fatalf("NewStaging() error = %v, want %v", err, file.ErrReplaceWorkingDir)
+	}
+	checkFiles(t, target, map[string]string{"old.txt": "old"})
+}
@@ -49,6 +49,77 @@ func TarDirectory(writer io.Writer, sourceDir string) (tarErr error) {
 	return tw.AddFS(os.DirFS(sourceDir))
 }
 
+// TarDirectoryFunc writes a tar archive of sourceDir to writer, where the
+// entries are named under prefix. Entries for which skip returns true are
+// omitted, and skipped directories are not walked. The path passed to skip is
+// relative to sourceDir.
+func TarDirectoryFunc(writer io.Writer, sourceDir string, prefix string, skip func(path string, isDir bool) bool) (tarErr error) {
+	tw := tar.NewWriter(writer)
+	defer func() {
+		closeErr := tw.Close()
+		if tarErr == nil {
+			tarErr = closeErr
+		}
+	}()
+
+	return filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		if rel != "." && skip != nil && skip(rel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		var link string
+		mode := info.Mode()
+		if mode&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		header.Name = filepath.ToSlash(filepath.Join(prefix, rel))
+		header.Uid = 0
+		header.Gid = 0
+		header.Uname = ""
+		header.Gname = ""
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("tar: %w", err)
+		}
+		if mode.IsRegular() {
+			return copyFile(tw, path)
+		}
+		return nil
+	})
+}
+
+func copyFile(w io.Writer, path string) (err error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := fp.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+	if _, err := io.Copy(w, fp); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", path, err)
+	}
+	return nil
+}
+
 // IsTarFile loosely checks whether the given file path refers to a tar archive
 // by examining its extension and magic number.
 func IsTarFile(path string) (bool, error) {
@@ -152,6 +152,53 @@ func TestTarDirectory_InvalidSource(t *testing.T) {
 	})
 }
 
+func TestTarDirectoryFunc(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"keep.txt", "skip.pdb", "sub/keep.txt", "skipped/foo.txt"} {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	skip := func(path string, isDir bool) bool {
+		return filepath.Ext(path) == ".pdb" || (isDir && path == "skipped")
+	}
+	if err := iotest.TarDirectoryFunc(&buf, tmpDir, "out", skip); err != nil {
+		t.Fatal("TarDirectoryFunc() error =", err)
+	}
+
+	var got []string
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal("failed to read tar:", err)
+		}
+		if header.Uid != 0 || header.Gid != 0 {
+			t.Errorf("entry %s is owned by %d:%d, want 0:0", header.Name, header.Uid, header.Gid)
+		}
+		got = append(got, header.Name)
+	}
+	want := []string{"out", "out/keep.txt", "out/sub", "out/sub/keep.txt"}
+	if len(got) != len(want) {
+		t.Fatalf("TarDirectoryFunc() entries = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("TarDirectoryFunc() entries = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
 func TestIsTarFile(t *testing.T) {
 	// Test case 1: File with .tar extension
 	t.Run("File with .tar extension", func(t *testing.T) {
@@ -0,0 +1,253 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mediatype detects media types of files from their content and
+// extensions.
+package mediatype
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Well-known media types which can be detected.
+const (
+	JSON      = "application/json"
+	Gzip      = "application/gzip"
+	Zstd      = "application/zstd"
+	Wasm      = "application/wasm"
+	HelmChart = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	SPDX      = "application/spdx+json"
+	CycloneDX = "application/vnd.cyclonedx+json"
+	InToto    = "application/vnd.in-toto+json"
+	DSSE      = "application/vnd.dsse.envelope.v1+json"
+)
+
+const (
+	// maxJSONSize is the maximum size of files sniffed as JSON documents.
+	maxJSONSize = 64 * 1024 * 1024
+	// maxTarEntries is the maximum number of tar entries inspected when
+	// looking for a Helm chart.
+	maxTarEntries = 64
+)
+
+// defaultExtensions maps well-known file extensions to media types.
+var defaultExtensions = map[string]string{
+	".json":         JSON,
+	".tar":          ocispec.MediaTypeImageLayer,
+	".tar.gz":       ocispec.MediaTypeImageLayerGzip,
+	".tgz":          ocispec.MediaTypeImageLayerGzip,
+	".gz":           Gzip,
+	".tar.zst":      ocispec.MediaTypeImageLayerZstd,
+	".tzst":         ocispec.MediaTypeImageLayerZstd,
+	".zst":          Zstd,
+	".wasm":         Wasm,
+	".spdx.json":    SPDX,
+	".cdx.json":     CycloneDX,
+	".intoto.json":  InToto,
+	".intoto.jsonl": InToto,
+}
+
+// Detector detects media types of files.
+type Detector struct {
+	extensions map[string]string
+}
+
+// NewDetector creates a detector. The extensions map file extensions, such as
+// ".tar.gz", to media types and take precedence over content sniffing and the
+// built-in extensions.
+func NewDetector(extensions map[string]string) *Detector {
+	normalized := make(map[string]string, len(extensions))
+	for ext, mediaType := range extensions {
+		normalized[strings.ToLower(ext)] = mediaType
+	}
+	return &Detector{
+		extensions: normalized,
+	}
+}
+
+// LoadMapping loads the extension-to-media-type mapping from a JSON file in
+// the form of {".ext": "media/type"}.
+func LoadMapping(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var mapping map[string]string
+	if err := json.Unmarshal(content, &mapping); err != nil {
+		return nil, fmt.Errorf("invalid media type mapping file %s: %w", path, err)
+	}
+	for ext, mediaType := range mapping {
+		if len(ext) < 2 || !strings.HasPrefix(ext, ".") || strings.ContainsAny(ext, `/\`) {
+			return nil, fmt.Errorf("invalid media type mapping file %s: invalid extension %q", path, ext)
+		}
+		if mediaType == "" {
+			return nil, fmt.Errorf("invalid media type mapping file %s: empty media type for extension %q", path, ext)
+		}
+	}
+	return mapping, nil
+}
+
+// Detect detects the media type of the regular file at path, which is pushed
+// with the given name. It returns an empty string if the media type cannot be
+// detected.
+func (d *Detector) Detect(filePath, name string) (string, error) {
+	if mediaType := lookup(d.extensions, name); mediaType != "" {
+		return mediaType, nil
+	}
+	mediaType, err := sniff(filePath, name)
+	if err != nil || mediaType != "" {
+		return mediaType, err
+	}
+	return lookup(defaultExtensions, name), nil
+}
+
+// DetectByName detects the media type only by the extension of name, for
+// content which cannot be sniffed such as stdin.
+func (d *Detector) DetectByName(name string) string {
+	if mediaType := lookup(d.extensions, name); mediaType != "" {
+		return mediaType
+	}
+	return lookup(defaultExtensions, name)
+}
+
+// lookup returns the media type of the longest extension of name found in
+// extensions.
+func lookup(extensions map[string]string, name string) string {
+	base := strings.ToLower(path.Base(strings.ReplaceAll(name, `\`, "/")))
+	for i := 0; i < len(base); i++ {
+		if base[i] != '.' {
+			continue
+		}
+		if mediaType, ok := extensions[base[i:]]; ok {
+			return mediaType
+		}
+	}
+	return ""
+}
+
+// sniff detects the media type of the file by its content.
+func sniff(filePath, name string) (string, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = fp.Close() }()
+	fi, err := fp.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	header := make([]byte, 512)
+	n, err := io.ReadFull(fp, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	header = header[:n]
+	if _, err := fp.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	switch {
+	case bytes.HasPrefix(header, []byte("\x00asm")):
+		return Wasm, nil
+	case bytes.HasPrefix(header, []byte("\x1f\x8b")):
+		return sniffGzip(fp), nil
+	case bytes.HasPrefix(header, []byte("\x28\xb5\x2f\xfd")):
+		if mediaType := lookup(defaultExtensions, name); mediaType == ocispec.MediaTypeImageLayerZstd {
+			return mediaType, nil
+		}
+		return Zstd, nil
+	case isTar(header):
+		return ocispec.MediaTypeImageLayer, nil
+	}
+	if trimmed := bytes.TrimLeft(header, " \t\r\n"); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && fi.Size() <= maxJSONSize {
+		return sniffJSON(fp), nil
+	}
+	return "", nil
+}
+
+// isTar returns true if the header is a POSIX or GNU tar header.
+func isTar(header []byte) bool {
+	return len(header) >= 262 && bytes.Equal(header[257:262], []byte("ustar"))
+}
+
+// sniffGzip distinguishes Helm charts and gzipped tarballs from other gzip
+// streams.
+func sniffGzip(r io.Reader) string {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return Gzip
+	}
+	defer func() { _ = zr.Close() }()
+	tr := tar.NewReader(zr)
+	for i := 0; i < maxTarEntries; i++ {
+		hdr, err := tr.Next()
+		if err != nil {
+			if i == 0 {
+				return Gzip
+			}
+			break
+		}
+		// a Helm chart contains <chart>/Chart.yaml
+		if dir, file := path.Split(strings.TrimPrefix(hdr.Name, "./")); file == "Chart.yaml" && dir != "" && !strings.Contains(strings.TrimSuffix(dir, "/"), "/") {
+			return HelmChart
+		}
+	}
+	return ocispec.MediaTypeImageLayerGzip
+}
+
+// sniffJSON validates the JSON document and detects well-known documents.
+func sniffJSON(r io.Reader) string {
+	var doc struct {
+		SPDXVersion string `json:"spdxVersion"`
+		BOMFormat   string `json:"bomFormat"`
+		Type        string `json:"_type"`
+		PayloadType string `json:"payloadType"`
+	}
+	decoder := json.NewDecoder(r)
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return ""
+	}
+	if err := decoder.Decode(&json.RawMessage{}); !errors.Is(err, io.EOF) {
+		// trailing content
+		return ""
+	}
+	if raw[0] != '{' || json.Unmarshal(raw, &doc) != nil {
+		return JSON
+	}
+	switch {
+	case strings.HasPrefix(doc.SPDXVersion, "SPDX-"):
+		return SPDX
+	case doc.BOMFormat == "CycloneDX":
+		return CycloneDX
+	case strings.HasPrefix(doc.Type, "https://in-toto.io/Statement/"):
+		return InToto
+	case doc.PayloadType == InToto:
+		return DSSE
+	}
+	return JSON
+}
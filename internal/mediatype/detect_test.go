@@ -0,0 +1,162 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mediatype
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func tarball(t *testing.T, names ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range names {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(name))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func gzipped(t *testing.T, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDetector_Detect(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{"data.bin", []byte(`{"foo": "bar"}`), JSON},
+		{"list.txt", []byte(` [1, 2, 3]`), JSON},
+		{"broken.json", []byte(`{"foo": `), JSON},
+		{"trailing.txt", []byte(`{} garbage`), ""},
+		{"sbom", []byte(`{"spdxVersion": "SPDX-2.3", "name": "foo"}`), SPDX},
+		{"bom.json", []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5"}`), CycloneDX},
+		{"statement", []byte(`{"_type": "https://in-toto.io/Statement/v1", "subject": []}`), InToto},
+		{"envelope", []byte(`{"payloadType": "application/vnd.in-toto+json", "payload": ""}`), DSSE},
+		{"module", []byte("\x00asm\x01\x00\x00\x00"), Wasm},
+		{"layer", tarball(t, "foo.txt"), ocispec.MediaTypeImageLayer},
+		{"layer.bin", gzipped(t, tarball(t, "foo.txt")), ocispec.MediaTypeImageLayerGzip},
+		{"chart.tgz", gzipped(t, tarball(t, "mychart/Chart.yaml", "mychart/values.yaml")), HelmChart},
+		{"nested.tgz", gzipped(t, tarball(t, "a/b/Chart.yaml")), ocispec.MediaTypeImageLayerGzip},
+		{"hello.gz", gzipped(t, []byte("hello")), Gzip},
+		{"data", []byte("\x28\xb5\x2f\xfd\x00"), Zstd},
+		{"layer.tar.zst", []byte("\x28\xb5\x2f\xfd\x00"), ocispec.MediaTypeImageLayerZstd},
+		{"empty.spdx.json", nil, SPDX},
+		{"README.MD", []byte("# hello"), ""},
+		{"hi.txt", []byte("hi"), ""},
+	}
+	d := NewDetector(nil)
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name)
+			if err := os.WriteFile(path, tt.content, 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := d.Detect(path, tt.name)
+			if err != nil {
+				t.Fatal("Detector.Detect() error =", err)
+			}
+			if got != tt.want {
+				t.Errorf("Detector.Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetector_Detect_mapping(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	d := NewDetector(map[string]string{".JSON": "application/vnd.example.report"})
+	got, err := d.Detect(path, "report.json")
+	if err != nil {
+		t.Fatal("Detector.Detect() error =", err)
+	}
+	if want := "application/vnd.example.report"; got != want {
+		t.Errorf("Detector.Detect() = %q, want %q", got, want)
+	}
+	if got := d.DetectByName("dir/Report.Json"); got != "application/vnd.example.report" {
+		t.Errorf("Detector.DetectByName() = %q, want %q", got, "application/vnd.example.report")
+	}
+	if got := d.DetectByName("module.wasm"); got != Wasm {
+		t.Errorf("Detector.DetectByName() = %q, want %q", got, Wasm)
+	}
+	if _, err := d.Detect(filepath.Join(dir, "missing"), "missing"); err == nil {
+		t.Error("Detector.Detect() error = nil, want error for missing file")
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"valid", `{".md": "text/markdown", ".tar.xz": "application/x-xz"}`, false},
+		{"not json", `.md: text/markdown`, true},
+		{"no dot", `{"md": "text/markdown"}`, true},
+		{"only dot", `{".": "text/markdown"}`, true},
+		{"path", `{".a/b": "text/markdown"}`, true},
+		{"empty type", `{".md": ""}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "mapping.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			mapping, err := LoadMapping(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadMapping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && mapping[".md"] != "text/markdown" {
+				t.Errorf("LoadMapping() = %v", mapping)
+			}
+		})
+	}
+	if _, err := LoadMapping(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadMapping() error = nil, want error for missing file")
+	}
+}
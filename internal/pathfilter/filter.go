@@ -0,0 +1,217 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pathfilter filters file paths with include and exclude glob
+// patterns in a gitignore-like syntax.
+package pathfilter
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is the name of the file listing the exclude patterns.
+const IgnoreFileName = ".orasignore"
+
+// rule is a parsed pattern.
+type rule struct {
+	// segments are the slash-separated segments of the pattern, where "**"
+	// matches zero or more segments.
+	segments []string
+	// anchored is true if the pattern matches paths from the root only.
+	anchored bool
+	// dirOnly is true if the pattern only matches directories.
+	dirOnly bool
+	// negated is true if the pattern re-includes paths.
+	negated bool
+}
+
+// Filter decides whether file paths are excluded. The paths are slash
+// separated and relative to the current working directory.
+//
+// Patterns follow the syntax of [path.Match] with the following extensions:
+//   - a pattern without a slash matches the base name at any depth;
+//   - a pattern with a leading or middle slash matches the whole path;
+//   - a trailing slash matches directories only;
+//   - "**" matches zero or more directories.
+//
+// A path matched by a pattern is also matched by the contents of the matched
+// directories.
+type Filter struct {
+	includes []rule
+	excludes []rule
+}
+
+// New creates a filter. If includes is not empty, only files matching any of
+// the include patterns are kept. Files matching the last matching exclude
+// pattern are excluded unless the pattern is negated with a leading "!".
+func New(includes, excludes []string) (*Filter, error) {
+	f := &Filter{}
+	for _, p := range includes {
+		r, err := parseRule(p, false)
+		if err != nil {
+			return nil, err
+		}
+		f.includes = append(f.includes, r)
+	}
+	for _, p := range excludes {
+		r, err := parseRule(p, true)
+		if err != nil {
+			return nil, err
+		}
+		f.excludes = append(f.excludes, r)
+	}
+	return f, nil
+}
+
+// LoadIgnoreFile reads the exclude patterns in the ignore file at path, one
+// per line. Blank lines and lines starting with "#" are ignored. A missing
+// file has no patterns.
+func LoadIgnoreFile(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var patterns []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return patterns, nil
+}
+
+// IsEmpty returns true if the filter has no patterns.
+func (f *Filter) IsEmpty() bool {
+	return f == nil || len(f.includes) == 0 && len(f.excludes) == 0
+}
+
+// Excluded returns true if the path, which is a directory if isDir is true,
+// is excluded. Directories are never excluded by the include patterns so that
+// their contents can be matched.
+func (f *Filter) Excluded(name string, isDir bool) bool {
+	if f.IsEmpty() {
+		return false
+	}
+	name = strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "./")
+	excluded := false
+	for _, r := range f.excludes {
+		if r.matchTree(name, isDir) {
+			excluded = !r.negated
+		}
+	}
+	if excluded || isDir || len(f.includes) == 0 {
+		return excluded
+	}
+	for _, r := range f.includes {
+		if r.matchTree(name, isDir) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseRule(pattern string, allowNegation bool) (rule, error) {
+	var r rule
+	p := strings.TrimSpace(filepath.ToSlash(pattern))
+	if allowNegation && strings.HasPrefix(p, "!") {
+		r.negated = true
+		p = p[1:]
+	}
+	if strings.HasSuffix(p, "/") {
+		r.dirOnly = true
+		p = strings.TrimRight(p, "/")
+	}
+	if strings.HasPrefix(p, "/") {
+		r.anchored = true
+		p = strings.TrimLeft(p, "/")
+	}
+	p = strings.TrimPrefix(p, "./")
+	if p == "" {
+		return rule{}, fmt.Errorf("invalid pattern %q: empty pattern", pattern)
+	}
+	if strings.Contains(p, "/") {
+		r.anchored = true
+	}
+	r.segments = strings.Split(p, "/")
+	for _, seg := range r.segments {
+		if _, err := path.Match(seg, ""); err != nil {
+			return rule{}, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return r, nil
+}
+
+// matchTree returns true if the rule matches the path or any of its parent
+// directories.
+func (r rule) matchTree(name string, isDir bool) bool {
+	segments := strings.Split(name, "/")
+	for i := len(segments); i > 0; i-- {
+		// parents are always directories
+		if r.match(segments[:i], isDir || i < len(segments)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r rule) match(segments []string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if !r.anchored {
+		ok, _ := path.Match(r.segments[0], segments[len(segments)-1])
+		return ok
+	}
+	return matchSegments(r.segments, segments)
+}
+
+// matchSegments matches path segments against pattern segments, where "**"
+// matches zero or more segments.
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
@@ -0,0 +1,115 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pathfilter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFilter_Excluded(t *testing.T) {
+	tests := []struct {
+		name     string
+		includes []string
+		excludes []string
+		path     string
+		isDir    bool
+		want     bool
+	}{
+		{"no pattern", nil, nil, "foo", false, false},
+		{"base name at any depth", nil, []string{"*.pdb"}, "bin/app.pdb", false, true},
+		{"base name not matched", nil, []string{"*.pdb"}, "bin/app", false, false},
+		{"anchored path", nil, []string{"bin/*.pdb"}, "bin/app.pdb", false, true},
+		{"anchored path at another depth", nil, []string{"bin/*.pdb"}, "out/bin/app.pdb", false, false},
+		{"leading slash", nil, []string{"/app.pdb"}, "bin/app.pdb", false, false},
+		{"double star", nil, []string{"**/cache/*"}, "a/b/cache/x", false, true},
+		{"double star matching zero directories", nil, []string{"**/cache/*"}, "cache/x", false, true},
+		{"contents of excluded directory", nil, []string{"node_modules"}, "web/node_modules/a/b.js", false, true},
+		{"directory only pattern on file", nil, []string{"tmp/"}, "tmp", false, false},
+		{"directory only pattern on directory", nil, []string{"tmp/"}, "tmp", true, true},
+		{"directory only pattern on contents", nil, []string{"tmp/"}, "tmp/foo", false, true},
+		{"negated", nil, []string{"*.log", "!keep.log"}, "logs/keep.log", false, false},
+		{"negated then excluded", nil, []string{"!keep.log", "*.log"}, "logs/keep.log", false, true},
+		{"dot slash", nil, []string{"*.pdb"}, "./app.pdb", false, true},
+		{"include matched", []string{"bin/linux-*"}, nil, "bin/linux-amd64", false, false},
+		{"include not matched", []string{"bin/linux-*"}, nil, "bin/windows-amd64.exe", false, true},
+		{"include directory", []string{"docs"}, nil, "docs/guide/a.md", false, false},
+		{"include never excludes directories", []string{"bin/linux-*"}, nil, "bin", true, false},
+		{"exclude wins over include", []string{"bin/*"}, []string{"*.pdb"}, "bin/app.pdb", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := New(tt.includes, tt.excludes)
+			if err != nil {
+				t.Fatal("New() error =", err)
+			}
+			if got := f.Excluded(tt.path, tt.isDir); got != tt.want {
+				t.Errorf("Filter.Excluded(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_invalid(t *testing.T) {
+	for _, pattern := range []string{"", "/", "[", "a/[/b"} {
+		if _, err := New(nil, []string{pattern}); err == nil {
+			t.Errorf("New() with exclude pattern %q error = nil, want error", pattern)
+		}
+	}
+	if _, err := New([]string{"!foo"}, nil); err != nil {
+		t.Errorf("New() with include pattern %q error = %v", "!foo", err)
+	}
+}
+
+func TestFilter_IsEmpty(t *testing.T) {
+	var f *Filter
+	if !f.IsEmpty() {
+		t.Error("nil Filter.IsEmpty() = false, want true")
+	}
+	if f.Excluded("foo", false) {
+		t.Error("nil Filter.Excluded() = true, want false")
+	}
+	f, err := New(nil, []string{"foo"})
+	if err != nil {
+		t.Fatal("New() error =", err)
+	}
+	if f.IsEmpty() {
+		t.Error("Filter.IsEmpty() = true, want false")
+	}
+}
+
+func TestLoadIgnoreFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, IgnoreFileName)
+	content := "# build outputs\n*.pdb\n\n  tmp/  \n!keep.pdb\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadIgnoreFile(path)
+	if err != nil {
+		t.Fatal("LoadIgnoreFile() error =", err)
+	}
+	if want := []string{"*.pdb", "tmp/", "!keep.pdb"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadIgnoreFile() = %v, want %v", got, want)
+	}
+
+	got, err = LoadIgnoreFile(filepath.Join(dir, "missing"))
+	if err != nil || got != nil {
+		t.Errorf("LoadIgnoreFile() = %v, %v, want nil, nil", got, err)
+	}
+}
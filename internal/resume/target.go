@@ -0,0 +1,344 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resume resumes interrupted downloads of large blobs.
+package resume
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/registry"
+)
+
+const (
+	// DirName is the name of the directory keeping the states of the
+	// downloads.
+	DirName = ".oras-partial"
+	// MinSize is the minimum size of a blob to be resumable.
+	MinSize = 4 * 1024 * 1024
+	// stateFileSuffix is the suffix of the state file of a download.
+	stateFileSuffix = ".json"
+)
+
+// locationKey is the context key of the location of the downloaded files.
+type locationKey struct{}
+
+// location is where the fetched blobs are saved.
+type location struct {
+	// dir is the directory of the files, where the states of the downloads
+	// are kept in the DirName directory under it.
+	dir string
+	// file, if not empty, is the file every blob is saved into. Otherwise,
+	// the blobs are saved into the files under dir named by their title
+	// annotations.
+	file string
+}
+
+// WithDir returns a context in which the blobs fetched from the targets
+// returned by New are resumable if they are saved by the file store under
+// dir, i.e. as the files named by their title annotations. The states of the
+// downloads are kept in the DirName directory under dir.
+func WithDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, locationKey{}, location{dir: dir})
+}
+
+// WithFile returns a context in which the blobs fetched from the targets
+// returned by New are resumable as they are saved into the file at path. The
+// states of the downloads are kept in the DirName directory next to the file.
+func WithFile(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, locationKey{}, location{dir: filepath.Dir(path), file: path})
+}
+
+// name returns the path of the file desc is saved into relative to the
+// directory, or an empty name if the file is unknown.
+func (l location) name(desc ocispec.Descriptor) string {
+	if l.file != "" {
+		return filepath.Base(l.file)
+	}
+	name := desc.Annotations[ocispec.AnnotationTitle]
+	if !filepath.IsLocal(name) || desc.Annotations[file.AnnotationUnpack] == "true" {
+		return ""
+	}
+	return name
+}
+
+// target resumes the interrupted downloads of the fetched blobs from the
+// files they are saved into.
+type target struct {
+	oras.ReadOnlyTarget
+	// inUse records the files being downloaded.
+	inUse sync.Map
+}
+
+// New returns a target fetching from source, resuming the downloads
+// interrupted in the previous runs. See [WithDir] for the blobs being
+// resumable.
+//
+// A blob being downloaded is written to its file only. Its state is recorded
+// in the DirName directory until the download completes, so that the content
+// written to the file by an interrupted download is reused on the next fetch.
+// A download is resumed with a range request if the reader fetched from
+// source implements [io.Seeker], which is the case for registries
+// advertising range support. Otherwise, the download is restarted. The
+// content is always verified against the descriptor.
+//
+// The returned target implements [registry.ReferenceFetcher] and
+// [content.PredecessorFinder] if source does. The content fetched by
+// reference is resumed only in the context returned by [WithFile].
+func New(source oras.ReadOnlyTarget) oras.ReadOnlyTarget {
+	t := &target{
+		ReadOnlyTarget: source,
+	}
+	refFetcher, isRefFetcher := source.(registry.ReferenceFetcher)
+	finder, isFinder := source.(content.PredecessorFinder)
+	switch {
+	case isRefFetcher && isFinder:
+		return &referenceGraphTarget{
+			referenceTarget: &referenceTarget{
+				target:           t,
+				ReferenceFetcher: refFetcher,
+			},
+			PredecessorFinder: finder,
+		}
+	case isRefFetcher:
+		return &referenceTarget{
+			target:           t,
+			ReferenceFetcher: refFetcher,
+		}
+	case isFinder:
+		return &graphTarget{
+			target:            t,
+			PredecessorFinder: finder,
+		}
+	}
+	return t
+}
+
+// referenceTarget is a target forwarding FetchReference to the source.
+type referenceTarget struct {
+	*target
+	registry.ReferenceFetcher
+}
+
+// FetchReference fetches the content identified by the reference. The
+// content is resolved and fetched separately for resuming if it is saved
+// into a file.
+func (t *referenceTarget) FetchReference(ctx context.Context, reference string) (ocispec.Descriptor, io.ReadCloser, error) {
+	if loc, ok := ctx.Value(locationKey{}).(location); !ok || loc.file == "" {
+		return t.ReferenceFetcher.FetchReference(ctx, reference)
+	}
+	desc, err := t.Resolve(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	rc, err := t.Fetch(ctx, desc)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	return desc, rc, nil
+}
+
+// graphTarget is a target forwarding Predecessors to the source.
+type graphTarget struct {
+	*target
+	content.PredecessorFinder
+}
+
+// referenceGraphTarget is a target forwarding FetchReference and Predecessors
+// to the source.
+type referenceGraphTarget struct {
+	*referenceTarget
+	content.PredecessorFinder
+}
+
+// Cleanup removes the directory of the download states under dir if it is
+// empty.
+func Cleanup(dir string) {
+	_ = os.Remove(filepath.Join(dir, DirName))
+}
+
+// Fetch fetches the content identified by the descriptor, resuming the
+// interrupted download if any.
+func (t *target) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	loc, ok := ctx.Value(locationKey{}).(location)
+	if !ok || desc.Size < MinSize || desc.Digest.Validate() != nil {
+		return t.ReadOnlyTarget.Fetch(ctx, desc)
+	}
+	name := loc.name(desc)
+	if name == "" {
+		return t.ReadOnlyTarget.Fetch(ctx, desc)
+	}
+	path := filepath.Join(loc.dir, name)
+	if _, loaded := t.inUse.LoadOrStore(path, true); loaded {
+		// the same file is being downloaded
+		return t.ReadOnlyTarget.Fetch(ctx, desc)
+	}
+	partialPath := filepath.Join(loc.dir, DirName, desc.Digest.Algorithm().String()+"-"+desc.Digest.Encoded())
+	rc, err := t.fetch(ctx, desc, name, path, partialPath)
+	if err != nil {
+		t.inUse.Delete(path)
+		return nil, err
+	}
+	rc.release = func() { t.inUse.Delete(path) }
+	return rc, nil
+}
+
+func (t *target) fetch(ctx context.Context, desc ocispec.Descriptor, name, path, partialPath string) (*reader, error) {
+	statePath := partialPath + stateFileSuffix
+	offset := movePartial(name, path, partialPath, statePath, desc)
+	rc, err := t.ReadOnlyTarget.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		seeker, ok := rc.(io.Seeker)
+		if !ok {
+			offset = 0
+		} else if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+			// range requests are not supported, restart the download
+			offset = 0
+		}
+	}
+	if err := saveState(statePath, name, desc); err != nil {
+		_ = rc.Close()
+		return nil, err
+	}
+
+	r := &reader{
+		rc:          rc,
+		partialPath: partialPath,
+		statePath:   statePath,
+	}
+	if offset == 0 {
+		_ = os.Remove(partialPath)
+		r.vr = content.NewVerifyReader(rc, desc)
+		return r, nil
+	}
+	if r.partial, err = os.Open(partialPath); err != nil {
+		_ = rc.Close()
+		return nil, err
+	}
+	r.vr = content.NewVerifyReader(io.MultiReader(io.NewSectionReader(r.partial, 0, offset), rc), desc)
+	return r, nil
+}
+
+// movePartial moves the file at path named name, which is partially
+// downloaded by an interrupted download of desc, aside to partialPath as the
+// file at path is to be overwritten. It returns the size of the content
+// downloaded.
+func movePartial(name, path, partialPath, statePath string, desc ocispec.Descriptor) int64 {
+	stateJSON, err := os.ReadFile(statePath)
+	if err != nil {
+		return 0
+	}
+	var state ocispec.Descriptor
+	if err := json.Unmarshal(stateJSON, &state); err != nil || !content.Equal(state, desc) ||
+		state.Annotations[ocispec.AnnotationTitle] != name {
+		return 0
+	}
+	// the content moved aside may be longer than the one written back if the
+	// resumed download is interrupted again
+	size := partialSize(partialPath, desc.Size)
+	if partialSize(path, desc.Size) > size {
+		if err := os.Rename(path, partialPath); err != nil {
+			return 0
+		}
+		size = partialSize(partialPath, desc.Size)
+	}
+	return size
+}
+
+// partialSize returns the size of the regular file at path if it is not
+// larger than size, or 0 otherwise.
+func partialSize(path string, size int64) int64 {
+	fi, err := os.Lstat(path)
+	if err != nil || !fi.Mode().IsRegular() || fi.Size() > size {
+		return 0
+	}
+	return fi.Size()
+}
+
+// saveState records the state of the download of desc into the file named
+// name.
+func saveState(statePath, name string, desc ocispec.Descriptor) error {
+	if err := os.MkdirAll(filepath.Dir(statePath), 0777); err != nil {
+		return err
+	}
+	stateJSON, err := json.Marshal(ocispec.Descriptor{
+		MediaType: desc.MediaType,
+		Digest:    desc.Digest,
+		Size:      desc.Size,
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: name,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, stateJSON, 0666)
+}
+
+// reader reads the partial download, if any, followed by the rest of the
+// content.
+type reader struct {
+	vr          *content.VerifyReader
+	rc          io.ReadCloser
+	partial     *os.File
+	partialPath string
+	statePath   string
+	// finished is true if the content is read through, either verified or
+	// corrupted.
+	finished bool
+	release  func()
+}
+
+// Read reads the content and verifies it at EOF.
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.vr.Read(p)
+	if err == io.EOF {
+		r.finished = true
+		if verifyErr := r.vr.Verify(); verifyErr != nil {
+			return n, verifyErr
+		}
+	}
+	return n, err
+}
+
+// Close closes the reader. The state of the download is removed if the
+// content is read through, or kept for resuming otherwise.
+func (r *reader) Close() error {
+	if r.release != nil {
+		defer r.release()
+	}
+	err := r.rc.Close()
+	if r.partial != nil {
+		err = errors.Join(err, r.partial.Close())
+	}
+	if r.finished {
+		_ = os.Remove(r.partialPath)
+		_ = os.Remove(r.statePath)
+	}
+	return err
+}
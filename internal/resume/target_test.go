@@ -0,0 +1,396 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resume
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// seekableTarget returns seekable readers and records the seek offsets.
+type seekableTarget struct {
+	oras.ReadOnlyTarget
+	blob     []byte
+	seekable bool
+	offsets  []int64
+}
+
+type seekableReader struct {
+	*bytes.Reader
+	target *seekableTarget
+}
+
+func (r *seekableReader) Seek(offset int64, whence int) (int64, error) {
+	r.target.offsets = append(r.target.offsets, offset)
+	return r.Reader.Seek(offset, whence)
+}
+
+func (r *seekableReader) Close() error {
+	return nil
+}
+
+func (t *seekableTarget) Fetch(_ context.Context, _ ocispec.Descriptor) (io.ReadCloser, error) {
+	if !t.seekable {
+		return io.NopCloser(bytes.NewReader(t.blob)), nil
+	}
+	return &seekableReader{Reader: bytes.NewReader(t.blob), target: t}, nil
+}
+
+func newBlob() ([]byte, ocispec.Descriptor) {
+	blob := bytes.Repeat([]byte("0123456789abcdef"), MinSize/16+1)
+	return blob, content.NewDescriptorFromBytes("application/octet-stream", blob)
+}
+
+// interrupt reads n bytes from the target into the file at path and closes
+// the reader.
+func interrupt(t *testing.T, ctx context.Context, target oras.ReadOnlyTarget, desc ocispec.Descriptor, path string, n int64) {
+	t.Helper()
+	rc, err := target.Fetch(ctx, desc)
+	if err != nil {
+		t.Fatal("Fetch() error =", err)
+	}
+	fp, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fp.Close()
+	if _, err := io.CopyN(fp, rc, n); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.Close(); err != nil {
+		t.Fatal("Close() error =", err)
+	}
+}
+
+// download fetches the content from the target into the file at path.
+func download(ctx context.Context, target oras.ReadOnlyTarget, desc ocispec.Descriptor, path string) error {
+	rc, err := target.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	fp, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	_, err = io.Copy(fp, rc)
+	return err
+}
+
+func statePath(dir string, desc ocispec.Descriptor) string {
+	return filepath.Join(dir, DirName, desc.Digest.Algorithm().String()+"-"+desc.Digest.Encoded()+stateFileSuffix)
+}
+
+func checkContent(t *testing.T, path string, want []byte) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("downloaded content mismatched")
+	}
+}
+
+func TestTarget_Fetch_resume(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blob")
+	ctx := WithFile(context.Background(), path)
+	blob, desc := newBlob()
+	source := &seekableTarget{blob: blob, seekable: true}
+	target := New(source)
+
+	interrupt(t, ctx, target, desc, path, 100)
+	if _, err := os.Stat(statePath(dir, desc)); err != nil {
+		t.Fatal("state file not kept:", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, DirName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("files in %s = %v, want the state file only", DirName, entries)
+	}
+
+	if err := download(ctx, target, desc, path); err != nil {
+		t.Fatal("download error =", err)
+	}
+	checkContent(t, path, blob)
+	if want := []int64{100}; len(source.offsets) != 1 || source.offsets[0] != want[0] {
+		t.Errorf("seek offsets = %v, want %v", source.offsets, want)
+	}
+	Cleanup(dir)
+	if _, err := os.Stat(filepath.Join(dir, DirName)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("state directory not removed: %v", err)
+	}
+}
+
+func TestTarget_Fetch_resumeInterruptedAgain(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blob")
+	ctx := WithFile(context.Background(), path)
+	blob, desc := newBlob()
+	source := &seekableTarget{blob: blob, seekable: true}
+	target := New(source)
+
+	interrupt(t, ctx, target, desc, path, 100)
+	// the resumed download is interrupted before the downloaded content is
+	// written back
+	interrupt(t, ctx, target, desc, path, 50)
+	if err := download(ctx, target, desc, path); err != nil {
+		t.Fatal("download error =", err)
+	}
+	checkContent(t, path, blob)
+	if want := []int64{100, 100}; !slices.Equal(source.offsets, want) {
+		t.Errorf("seek offsets = %v, want %v", source.offsets, want)
+	}
+}
+
+func TestTarget_Fetch_dir(t *testing.T) {
+	dir := t.TempDir()
+	ctx := WithDir(context.Background(), dir)
+	blob, desc := newBlob()
+	desc.Annotations = map[string]string{ocispec.AnnotationTitle: "blob"}
+	source := &seekableTarget{blob: blob, seekable: true}
+	target := New(source)
+
+	path := filepath.Join(dir, "blob")
+	interrupt(t, ctx, target, desc, path, 100)
+	if err := download(ctx, target, desc, path); err != nil {
+		t.Fatal("download error =", err)
+	}
+	checkContent(t, path, blob)
+	if want := []int64{100}; !slices.Equal(source.offsets, want) {
+		t.Errorf("seek offsets = %v, want %v", source.offsets, want)
+	}
+
+	// files to be unpacked or outside of the directory are not resumed
+	for _, annotations := range []map[string]string{
+		{ocispec.AnnotationTitle: "blob", file.AnnotationUnpack: "true"},
+		{ocispec.AnnotationTitle: "../blob"},
+		{},
+	} {
+		desc.Annotations = annotations
+		interrupt(t, ctx, target, desc, path, 100)
+		if _, err := os.Stat(statePath(dir, desc)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("state file created for %v: %v", annotations, err)
+		}
+	}
+}
+
+func TestTarget_Fetch_restartWithoutRange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blob")
+	ctx := WithFile(context.Background(), path)
+	blob, desc := newBlob()
+	target := New(&seekableTarget{blob: blob})
+
+	interrupt(t, ctx, target, desc, path, 100)
+	if err := download(ctx, target, desc, path); err != nil {
+		t.Fatal("download error =", err)
+	}
+	checkContent(t, path, blob)
+}
+
+func TestTarget_Fetch_corruptedPartial(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blob")
+	ctx := WithFile(context.Background(), path)
+	blob, desc := newBlob()
+	target := New(&seekableTarget{blob: blob, seekable: true})
+
+	interrupt(t, ctx, target, desc, path, 100)
+	if err := os.WriteFile(path, bytes.Repeat([]byte("x"), 100), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := download(ctx, target, desc, path); !errors.Is(err, content.ErrMismatchedDigest) {
+		t.Errorf("download error = %v, want %v", err, content.ErrMismatchedDigest)
+	}
+	if _, err := os.Stat(statePath(dir, desc)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("state of corrupted download not removed: %v", err)
+	}
+}
+
+func TestTarget_Fetch_stateMismatched(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blob")
+	ctx := WithFile(context.Background(), path)
+	blob, desc := newBlob()
+	source := &seekableTarget{blob: blob, seekable: true}
+	target := New(source)
+
+	interrupt(t, ctx, target, desc, path, 100)
+	if err := os.WriteFile(statePath(dir, desc), []byte(`{"mediaType":"text/plain"}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := download(ctx, target, desc, path); err != nil {
+		t.Fatal("download error =", err)
+	}
+	if len(source.offsets) != 0 {
+		t.Errorf("seek offsets = %v, want none", source.offsets)
+	}
+
+	// the file is downloaded to another path
+	otherPath := filepath.Join(dir, "other")
+	interrupt(t, ctx, target, desc, path, 100)
+	if err := download(WithFile(context.Background(), otherPath), target, desc, otherPath); err != nil {
+		t.Fatal("download error =", err)
+	}
+	if len(source.offsets) != 0 {
+		t.Errorf("seek offsets = %v, want none", source.offsets)
+	}
+}
+
+func TestTarget_Fetch_notResumable(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blob")
+	source := memory.New()
+	blob := []byte("hello")
+	desc := ocispec.Descriptor{
+		MediaType: "text/plain",
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	if err := source.Push(context.Background(), desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal(err)
+	}
+	target := New(source)
+	interrupt(t, WithFile(context.Background(), path), target, desc, path, 1)
+	if _, err := os.Stat(filepath.Join(dir, DirName)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("state directory created for small blob: %v", err)
+	}
+
+	// no location in the context
+	blob, desc = newBlob()
+	target = New(&seekableTarget{blob: blob, seekable: true})
+	interrupt(t, context.Background(), target, desc, path, 100)
+	if _, err := os.Stat(filepath.Join(dir, DirName)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("state directory created without location: %v", err)
+	}
+}
+
+func TestNew_interfaces(t *testing.T) {
+	repo, err := remote.NewRepository("localhost:5000/test")
+	if err != nil {
+		t.Fatal("NewRepository() error =", err)
+	}
+	tests := []struct {
+		name           string
+		source         oras.ReadOnlyTarget
+		wantRefFetcher bool
+		wantFinder     bool
+	}{
+		{"repository", repo, true, true},
+		{"blob store", repo.Blobs(), true, false},
+		{"memory", memory.New(), false, true},
+		{"target", &seekableTarget{}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := New(tt.source)
+			if _, ok := target.(registry.ReferenceFetcher); ok != tt.wantRefFetcher {
+				t.Errorf("New() implements ReferenceFetcher = %v, want %v", ok, tt.wantRefFetcher)
+			}
+			if _, ok := target.(content.PredecessorFinder); ok != tt.wantFinder {
+				t.Errorf("New() implements PredecessorFinder = %v, want %v", ok, tt.wantFinder)
+			}
+		})
+	}
+}
+
+func TestTarget_FetchReference_remote(t *testing.T) {
+	blob, desc := newBlob()
+	var lock sync.Mutex
+	var ranges []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/test/blobs/"+desc.Digest.String() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		lock.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		lock.Unlock()
+		w.Header().Set("Content-Type", desc.MediaType)
+		w.Header().Set("Docker-Content-Digest", desc.Digest.String())
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(blob))
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := remote.NewRepository(uri.Host + "/test")
+	if err != nil {
+		t.Fatal("NewRepository() error =", err)
+	}
+	repo.PlainHTTP = true
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blob")
+	ctx := WithFile(context.Background(), path)
+	target := New(repo.Blobs()).(registry.ReferenceFetcher)
+
+	fetch := func(n int64) {
+		t.Helper()
+		_, rc, err := target.FetchReference(ctx, desc.Digest.String())
+		if err != nil {
+			t.Fatal("FetchReference() error =", err)
+		}
+		defer rc.Close()
+		fp, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer fp.Close()
+		if n < 0 {
+			_, err = io.Copy(fp, rc)
+		} else {
+			_, err = io.CopyN(fp, rc, n)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	fetch(100)
+	fetch(-1)
+	checkContent(t, path, blob)
+	lock.Lock()
+	defer lock.Unlock()
+	if want := "bytes=100-" + strconv.FormatInt(desc.Size-1, 10); len(ranges) == 0 || ranges[len(ranges)-1] != want {
+		t.Errorf("range requests = %q, want the last one %q", ranges, want)
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signature signs and verifies artifacts with local ed25519 keys.
+package signature
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// ArtifactType is the artifact type of signature manifests.
+	ArtifactType = "application/vnd.oras.signature.v1"
+	// MediaType is the media type of the signature envelope layer.
+	MediaType = "application/vnd.oras.signature.ed25519.v1+json"
+	// MaxEnvelopeSize is the maximum size of a signature envelope.
+	MaxEnvelopeSize = 64 * 1024
+)
+
+var (
+	// ErrInvalidKey is returned when a key file does not contain an ed25519
+	// key.
+	ErrInvalidKey = errors.New("invalid ed25519 key")
+	// ErrInvalidSignature is returned when a signature cannot be verified.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrKeyMismatch is returned when a signature is signed by another key.
+	ErrKeyMismatch = errors.New("signature is signed by another key")
+)
+
+// Payload is the signed content, identifying the subject of the signature.
+type Payload struct {
+	MediaType string        `json:"mediaType"`
+	Digest    digest.Digest `json:"digest"`
+	Size      int64         `json:"size"`
+}
+
+// Envelope is the content of the signature layer.
+type Envelope struct {
+	// Payload is the signed payload in JSON.
+	Payload []byte `json:"payload"`
+	// KeyID is the digest of the public key in PKIX, ASN.1 DER form.
+	KeyID string `json:"keyID"`
+	// Signature is the ed25519 signature over the payload.
+	Signature []byte `json:"signature"`
+}
+
+// LoadPrivateKey loads an ed25519 private key from a PEM-encoded PKCS #8 file.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	block, err := loadPEM(path, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %v", path, ErrInvalidKey, err)
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: unsupported private key type %T", path, ErrInvalidKey, key)
+	}
+	return privateKey, nil
+}
+
+// LoadPublicKey loads an ed25519 public key from a PEM-encoded PKIX file.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	block, err := loadPEM(path, "PUBLIC KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %v", path, ErrInvalidKey, err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: unsupported public key type %T", path, ErrInvalidKey, key)
+	}
+	return publicKey, nil
+}
+
+func loadPEM(path string, blockType string) (*pem.Block, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("%s: %w: no PEM data found", path, ErrInvalidKey)
+	}
+	if block.Type != blockType {
+		return nil, fmt.Errorf("%s: %w: expecting PEM block type %q but got %q", path, ErrInvalidKey, blockType, block.Type)
+	}
+	return block, nil
+}
+
+// KeyID returns the identifier of the public key.
+func KeyID(key ed25519.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return digest.NewDigestFromBytes(digest.SHA256, sum[:]).String(), nil
+}
+
+// Sign signs the subject and returns the signature envelope in JSON.
+func Sign(key ed25519.PrivateKey, subject ocispec.Descriptor) ([]byte, error) {
+	payload, err := json.Marshal(Payload{
+		MediaType: subject.MediaType,
+		Digest:    subject.Digest,
+		Size:      subject.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := KeyID(key.Public().(ed25519.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(Envelope{
+		Payload:   payload,
+		KeyID:     keyID,
+		Signature: ed25519.Sign(key, payload),
+	})
+}
+
+// Verify verifies the signature envelope in JSON against the subject and the
+// public key.
+func Verify(key ed25519.PublicKey, subject ocispec.Descriptor, envelopeJSON []byte) error {
+	var envelope Envelope
+	if err := json.Unmarshal(envelopeJSON, &envelope); err != nil {
+		return fmt.Errorf("%w: malformed envelope: %v", ErrInvalidSignature, err)
+	}
+	keyID, err := KeyID(key)
+	if err != nil {
+		return err
+	}
+	if envelope.KeyID != keyID {
+		return fmt.Errorf("%w: %s", ErrKeyMismatch, envelope.KeyID)
+	}
+	if !ed25519.Verify(key, envelope.Payload, envelope.Signature) {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidSignature)
+	}
+	var payload Payload
+	if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+		return fmt.Errorf("%w: malformed payload: %v", ErrInvalidSignature, err)
+	}
+	if payload.MediaType != subject.MediaType || payload.Digest != subject.Digest || payload.Size != subject.Size {
+		return fmt.Errorf("%w: signed for %s instead of %s", ErrInvalidSignature, payload.Digest, subject.Digest)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+var subject = ocispec.Descriptor{
+	MediaType: ocispec.MediaTypeImageManifest,
+	Digest:    digest.FromString("subject"),
+	Size:      7,
+}
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func generateKeyFiles(t *testing.T) (privatePath, publicPath string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writePEM(t, "PRIVATE KEY", privDER), writePEM(t, "PUBLIC KEY", pubDER)
+}
+
+func TestSign_Verify(t *testing.T) {
+	privatePath, publicPath := generateKeyFiles(t)
+	privateKey, err := LoadPrivateKey(privatePath)
+	if err != nil {
+		t.Fatal("LoadPrivateKey() error =", err)
+	}
+	publicKey, err := LoadPublicKey(publicPath)
+	if err != nil {
+		t.Fatal("LoadPublicKey() error =", err)
+	}
+
+	envelope, err := Sign(privateKey, subject)
+	if err != nil {
+		t.Fatal("Sign() error =", err)
+	}
+	if err := Verify(publicKey, subject, envelope); err != nil {
+		t.Fatal("Verify() error =", err)
+	}
+
+	// another subject
+	other := subject
+	other.Digest = digest.FromString("other")
+	if err := Verify(publicKey, other, envelope); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() error = %v, want %v", err, ErrInvalidSignature)
+	}
+
+	// another key
+	anotherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(anotherPublicKey, subject, envelope); !errors.Is(err, ErrKeyMismatch) {
+		t.Errorf("Verify() error = %v, want %v", err, ErrKeyMismatch)
+	}
+
+	// tampered payload
+	var e Envelope
+	if err := json.Unmarshal(envelope, &e); err != nil {
+		t.Fatal(err)
+	}
+	e.Payload = []byte(`{"mediaType":"foo"}`)
+	tampered, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(publicKey, subject, tampered); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() error = %v, want %v", err, ErrInvalidSignature)
+	}
+
+	// malformed envelope
+	if err := Verify(publicKey, subject, []byte("foo")); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() error = %v, want %v", err, ErrInvalidSignature)
+	}
+}
+
+func TestLoadKey_invalid(t *testing.T) {
+	privatePath, publicPath := generateKeyFiles(t)
+	if _, err := LoadPrivateKey(publicPath); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("LoadPrivateKey() error = %v, want %v", err, ErrInvalidKey)
+	}
+	if _, err := LoadPublicKey(privatePath); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("LoadPublicKey() error = %v, want %v", err, ErrInvalidKey)
+	}
+
+	notPEM := filepath.Join(t.TempDir(), "key.txt")
+	if err := os.WriteFile(notPEM, []byte("foo"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPrivateKey(notPEM); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("LoadPrivateKey() error = %v, want %v", err, ErrInvalidKey)
+	}
+	if _, err := LoadPublicKey(filepath.Join(t.TempDir(), "missing.pem")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadPublicKey() error = %v, want %v", err, os.ErrNotExist)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPrivateKey(writePEM(t, "PRIVATE KEY", der)); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("LoadPrivateKey() error = %v, want %v", err, ErrInvalidKey)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"oras.land/oras/test/e2e/internal/testdata/foobar"
+	. "oras.land/oras/test/e2e/internal/utils"
+)
+
+var _ = Describe("ORAS beginners:", func() {
+	When("running cache command", func() {
+		It("should show help doc for cache prune", func() {
+			ORAS("cache", "prune", "--help").MatchKeyWords("--older-than", "--max-size", "--dry-run", ExampleDesc).Exec()
+		})
+
+		It("should fail if no blobs are selected to prune", func() {
+			ORAS("cache", "prune").ExpectFailure().MatchErrKeyWords("Error:", "--older-than").Exec()
+		})
+
+		It("should fail if the duration is invalid", func() {
+			ORAS("cache", "prune", "--older-than", "1w").ExpectFailure().MatchErrKeyWords("Error:", "invalid duration").Exec()
+		})
+	})
+})
+
+var _ = Describe("OCI image layout users:", func() {
+	When("running cache command", func() {
+		It("should populate the cache on push and clear it", func() {
+			tempDir := PrepareTempFiles()
+			cacheDir := filepath.Join(tempDir, "cache")
+			ORAS("push", Flags.Layout, LayoutRef(tempDir, "cached"), foobar.FileBarName, "--cache", cacheDir, "--populate-cache").
+				WithWorkDir(tempDir).Exec()
+			bar := foobar.BlobBarDescriptor("application/vnd.oci.image.layer.v1.tar")
+			ORAS("cache", "ls", "--cache", cacheDir).MatchKeyWords(bar.Digest.String()).Exec()
+			ORAS("cache", "du", "--cache", cacheDir, "--format", "json").MatchKeyWords(`"count": 3`).Exec()
+			ORAS("cache", "prune", "--cache", cacheDir, "--max-size", "0", "--dry-run", "--format", "json").
+				MatchKeyWords(`"dryRun": true`, bar.Digest.String()).Exec()
+			ORAS("cache", "clear", "--cache", cacheDir, "--format", "json").MatchKeyWords(`"root"`).Exec()
+			ORAS("cache", "du", "--cache", cacheDir, "--format", "json").MatchKeyWords(`"count": 0`).Exec()
+		})
+	})
+})
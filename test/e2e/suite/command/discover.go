@@ -97,6 +97,27 @@ var _ = Describe("ORAS beginners:", func() {
 				Exec()
 		})
 
+		It("should fail if contents are included without graph format", func() {
+			ORAS("discover", RegistryRef(ZOTHost, ImageRepo, foobar.Tag), "--include-content").
+				ExpectFailure().
+				MatchErrKeyWords("`--include-content` can only be used with `--format dot` or `--format mermaid`").
+				Exec()
+		})
+
+		It("should fail if ancestors are discovered with depth", func() {
+			ORAS("discover", RegistryRef(ZOTHost, ImageRepo, foobar.Tag), "--ancestors", "--depth", "1").
+				ExpectFailure().
+				MatchErrKeyWords("Error:", "--ancestors", "--depth").
+				Exec()
+		})
+
+		It("should fail if given an invalid sort key", func() {
+			ORAS("discover", RegistryRef(ZOTHost, ImageRepo, foobar.Tag), "--sort", "size").
+				ExpectFailure().
+				MatchErrKeyWords("Error:", `invalid sort key "size"`).
+				Exec()
+		})
+
 		It("should fail if given an invalid value for depth", func() {
 			ORAS("discover", RegistryRef(ZOTHost, ImageRepo, foobar.Tag), "--depth", "0").
 				ExpectFailure().
@@ -178,6 +199,24 @@ var _ = Describe("1.1 registry users:", func() {
 			Expect(subject.Referrers).To(HaveLen(0))
 		})
 
+		It("should discover matched referrers at every depth when filtering by annotation and creation time", func() {
+			bytes := ORAS("discover", subjectRef, "--format", format, "--annotation-exists", "org.opencontainers.image.created", "--created-after", "2023-01-18", "--latest").
+				Exec().Out.Contents()
+			var subject subject
+			Expect(json.Unmarshal(bytes, &subject)).ShouldNot(HaveOccurred())
+			Expect(subject.Referrers).To(HaveLen(1))
+			Expect(subject.Referrers[0].Descriptor).Should(Equal(foobar.SBOMImageReferrer))
+			Expect(subject.Referrers[0].Referrers).To(HaveLen(1))
+			Expect(subject.Referrers[0].Referrers[0]).Should(Equal(foobar.SignatureImageReferrer))
+		})
+
+		It("should discover no referrer created before the time", func() {
+			bytes := ORAS("discover", subjectRef, "--format", format, "--created-before", "2023-01-18T08:37:00Z").Exec().Out.Contents()
+			var subject subject
+			Expect(json.Unmarshal(bytes, &subject)).ShouldNot(HaveOccurred())
+			Expect(subject.Referrers).To(HaveLen(0))
+		})
+
 		It("should discover one referrer with matched platform", func() {
 			bytes := ORAS("discover", RegistryRef(ZOTHost, ArtifactRepo, multi_arch.Tag), "--format", format, "--platform", "linux/amd64").
 				Exec().Out.Contents()
@@ -187,6 +226,20 @@ var _ = Describe("1.1 registry users:", func() {
 			Expect(subject.Referrers[0].Descriptor).Should(Equal(multi_arch.LinuxAMD64Referrer))
 		})
 
+		It("should discover referrers of the child manifests grouped by platform", func() {
+			bytes := ORAS("discover", RegistryRef(ZOTHost, ArtifactRepo, multi_arch.Tag), "--format", format, "--include-manifests").
+				Exec().Out.Contents()
+			var index struct {
+				ocispec.Descriptor
+				Manifests []subject
+			}
+			Expect(json.Unmarshal(bytes, &index)).ShouldNot(HaveOccurred())
+			Expect(index.Manifests).To(HaveLen(3))
+			Expect(index.Manifests[0].Platform).Should(Equal(multi_arch.LinuxAMD64.Platform))
+			Expect(index.Manifests[0].Referrers).To(HaveLen(1))
+			Expect(index.Manifests[0].Referrers[0].Descriptor).Should(Equal(multi_arch.LinuxAMD64Referrer))
+		})
+
 		It("should discover referrers correctly by depth 1", func() {
 			bytes := ORAS("discover", subjectRef, "--format", format, "--depth", "1").Exec().Out.Contents()
 			var subject subject
@@ -296,6 +349,33 @@ var _ = Describe("1.1 registry users:", func() {
 			Expect(out).To(gbytes.Say(indirectReferrers.Digest.String()))
 		})
 	})
+	When("running discover command with ancestors", func() {
+		It("should discover the subject chain of a signature via json output", func() {
+			bytes := ORAS("discover", RegistryRef(ZOTHost, ArtifactRepo, foobar.SignatureImageReferrer.Digest.String()), "--ancestors", "--format", "json").Exec().Out.Contents()
+			var ancestors []struct {
+				ocispec.Descriptor
+				Tags []string
+			}
+			Expect(json.Unmarshal(bytes, &ancestors)).ShouldNot(HaveOccurred())
+			Expect(ancestors).To(HaveLen(3))
+			Expect(ancestors[0].Digest).Should(Equal(foobar.SignatureImageReferrer.Digest))
+			Expect(ancestors[1].Digest).Should(Equal(foobar.SBOMImageReferrer.Digest))
+			Expect(ancestors[2].Digest).Should(Equal(foobar.FooBar.Digest))
+			Expect(ancestors[2].Tags).Should(ContainElement(foobar.Tag))
+		})
+		It("should discover the subject chain of a signature via tree output", func() {
+			ORAS("discover", RegistryRef(ZOTHost, ArtifactRepo, foobar.SignatureImageReferrer.Digest.String()), "--ancestors").
+				MatchKeyWords("[subject]", "[tags]", foobar.SBOMImageReferrer.Digest.String(), foobar.FooBar.Digest.String()).
+				Exec()
+		})
+	})
+	When("running discover command with tree output including manifests", func() {
+		It("should group the child manifests by platform", func() {
+			ORAS("discover", RegistryRef(ZOTHost, ArtifactRepo, multi_arch.Tag), "--include-manifests").
+				MatchKeyWords("[platform] linux/amd64", "[platform] linux/arm64", "[platform] linux/arm/v7", multi_arch.LinuxAMD64.Digest.String(), multi_arch.LinuxAMD64Referrer.Digest.String()).
+				Exec()
+		})
+	})
 	When("running discover command with table output", func() {
 		format := "table"
 		It("should show all referrers of a subject", func() {
@@ -305,6 +385,19 @@ var _ = Describe("1.1 registry users:", func() {
 				Exec()
 		})
 	})
+	When("running discover command with graph output", func() {
+		It("should export the artifact graph in DOT language", func() {
+			ORAS("discover", subjectRef, "--format", "dot").
+				MatchKeyWords("digraph {", foobar.Digest, foobar.SBOMImageReferrer.Digest.String(), foobar.SignatureImageReferrer.Digest.String(), `[label="subject", style=dashed]`).
+				Exec()
+		})
+
+		It("should export the artifact graph with contents as a Mermaid flowchart", func() {
+			ORAS("discover", subjectRef, "--format", "mermaid", "--include-content").
+				MatchKeyWords("flowchart TD", "-.->|subject|", "-->", ocispec.MediaTypeImageLayer).
+				Exec()
+		})
+	})
 	When("running discover command with go-template output", func() {
 		It("should show referrers digest of a subject", func() {
 			ORAS("discover", subjectRef, "--format", "go-template={{(first .referrers).reference}}").
@@ -0,0 +1,91 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/test/e2e/internal/testdata/feature"
+	"oras.land/oras/test/e2e/internal/testdata/foobar"
+	"oras.land/oras/test/e2e/internal/testdata/multi_arch"
+	. "oras.land/oras/test/e2e/internal/utils"
+)
+
+var _ = Describe("ORAS beginners:", func() {
+	When("running inspect command", func() {
+		It("should show help description with feature mark", func() {
+			out := ORAS("inspect", "--help").MatchKeyWords(ExampleDesc).Exec().Out
+			Expect(out.Contents()).Should(HavePrefix(feature.Preview.Mark))
+		})
+
+		It("should fail if given an unsupported format", func() {
+			ORAS("inspect", RegistryRef(ZOTHost, ImageRepo, foobar.Tag), "--format", "table").
+				ExpectFailure().
+				MatchErrKeyWords("Error:", "invalid format type", "tree", "json", "go-template").
+				Exec()
+		})
+
+		It("should fail and show detailed error description if no argument provided", func() {
+			err := ORAS("inspect").ExpectFailure().Exec().Err
+			Expect(err).Should(gbytes.Say("Error"))
+			Expect(err).Should(gbytes.Say("\nUsage: oras inspect"))
+			Expect(err).Should(gbytes.Say("\n"))
+			Expect(err).Should(gbytes.Say(`Run "oras inspect -h"`))
+		})
+	})
+})
+
+var _ = Describe("1.1 registry users:", func() {
+	When("running inspect command", func() {
+		It("should inspect an artifact with its tags, layers and referrers via tree output", func() {
+			ORAS("inspect", RegistryRef(ZOTHost, ArtifactRepo, foobar.Tag), "--show-tags").
+				MatchKeyWords("[tags]", foobar.Tag, "[config]", "[layers]", foobar.FileBarName, "[total size]", "[referrers]", foobar.SBOMImageReferrer.Digest.String(), foobar.SignatureImageReferrer.Digest.String()).
+				Exec()
+		})
+
+		It("should inspect a multi-arch image grouped by platform via json output", func() {
+			bytes := ORAS("inspect", RegistryRef(ZOTHost, ArtifactRepo, multi_arch.Tag), "--show-tags", "--format", "json").Exec().Out.Contents()
+			var inspected struct {
+				ocispec.Descriptor
+				Tags      []string
+				Manifests []struct {
+					ocispec.Descriptor
+					Layers []ocispec.Descriptor
+				}
+				TotalSize int64
+				Referrers []ocispec.Descriptor
+			}
+			Expect(json.Unmarshal(bytes, &inspected)).ShouldNot(HaveOccurred())
+			Expect(inspected.Digest.String()).Should(Equal(multi_arch.Digest))
+			Expect(inspected.Tags).Should(ContainElement(multi_arch.Tag))
+			Expect(inspected.Manifests).To(HaveLen(3))
+			Expect(inspected.Manifests[0].Digest).Should(Equal(multi_arch.LinuxAMD64.Digest))
+			Expect(inspected.Manifests[0].Platform).Should(Equal(multi_arch.LinuxAMD64.Platform))
+			Expect(inspected.Manifests[0].Layers).NotTo(BeEmpty())
+			Expect(inspected.TotalSize).Should(BeNumerically(">", multi_arch.DescriptorObject.Size))
+		})
+
+		It("should inspect the platform manifest of a multi-arch image", func() {
+			ORAS("inspect", RegistryRef(ZOTHost, ArtifactRepo, multi_arch.Tag), "--platform", "linux/amd64", "--format", "go-template", "--template", "{{.digest}}").
+				MatchKeyWords(multi_arch.LinuxAMD64.Digest.String()).
+				Exec()
+		})
+	})
+})
@@ -249,6 +249,36 @@ var _ = Describe("ORAS beginners:", func() {
 				ORAS("manifest", "fetch-config", RegistryRef(ZOTHost, ImageRepo, multi_arch.Tag)).ExpectFailure().Exec()
 			})
 		})
+		When("running `manifest diff`", func() {
+			It("should show help doc with format flag", func() {
+				ORAS("manifest", "diff", "--help").MatchKeyWords(ExampleDesc, "--format").Exec()
+			})
+
+			It("should fail if only one argument provided", func() {
+				ORAS("manifest", "diff", RegistryRef(ZOTHost, ImageRepo, foobar.Tag)).ExpectFailure().
+					MatchErrKeyWords("Error:", "oras manifest diff").Exec()
+			})
+
+			It("should fail if both manifests are read from stdin", func() {
+				ORAS("manifest", "diff", "-", "-").ExpectFailure().
+					MatchErrKeyWords("Error:", "stdin").Exec()
+			})
+		})
+		When("running `manifest validate`", func() {
+			It("should show help doc with format flag", func() {
+				ORAS("manifest", "validate", "--help").MatchKeyWords(ExampleDesc, "--format", "--media-type").Exec()
+			})
+
+			It("should fail if no argument provided", func() {
+				ORAS("manifest", "validate").ExpectFailure().
+					MatchErrKeyWords("Error:", "oras manifest validate").Exec()
+			})
+
+			It("should fail if media type flag is used with a reference", func() {
+				ORAS("manifest", "validate", RegistryRef(ZOTHost, ImageRepo, foobar.Tag), "--media-type", "application/vnd.oci.image.manifest.v1+json").ExpectFailure().
+					MatchErrKeyWords("Error:", "--media-type", "local file").Exec()
+			})
+		})
 	})
 })
 
@@ -443,6 +473,71 @@ var _ = Describe("1.1 registry users:", func() {
 			ORAS("manifest", "push", RegistryRef(ZOTHost, ImageRepo, tag), manifestPath).
 				WithInput(strings.NewReader(manifest)).ExpectFailure().MatchErrKeyWords("Error:", " media type is not specified", "oras manifest push").Exec()
 		})
+
+		It("should validate a manifest before pushing it", func() {
+			tag := "validated"
+			ORAS("manifest", "push", RegistryRef(ZOTHost, ImageRepo, tag), "-", "--validate").
+				MatchKeyWords("Validated", "[warning] layers", "Pushed", RegistryRef(ZOTHost, ImageRepo, tag), "Digest:", digest).
+				WithInput(strings.NewReader(manifest)).Exec()
+		})
+
+		It("should fail to push a manifest referencing a missing blob with validation", func() {
+			missing := `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae","size":3},"layers":[]}`
+			ORAS("manifest", "push", RegistryRef(ZOTHost, ImageRepo, "invalid"), "-", "--validate").
+				WithInput(strings.NewReader(missing)).ExpectFailure().
+				MatchKeyWords("[error] config references", "not found").
+				MatchErrKeyWords("manifest validation failed", "--validate").Exec()
+			ORAS("manifest", "fetch", RegistryRef(ZOTHost, ImageRepo, "invalid")).ExpectFailure().Exec()
+		})
+	})
+
+	When("running `manifest diff`", func() {
+		It("should find no differences between the same manifest", func() {
+			ORAS("manifest", "diff", RegistryRef(ZOTHost, ImageRepo, multi_arch.Tag), RegistryRef(ZOTHost, ImageRepo, multi_arch.Digest)).
+				MatchKeyWords("No differences found").Exec()
+		})
+
+		It("should compare a manifest from file with a manifest in registry", func() {
+			manifestPath := WriteTempFile("manifest.json", multi_arch.LinuxAMD64Manifest)
+			ORAS("manifest", "diff", manifestPath, RegistryRef(ZOTHost, ImageRepo, multi_arch.Tag), "--platform", "linux/amd64").
+				MatchKeyWords("No differences found").Exec()
+		})
+
+		It("should compare a manifest with an index and output json", func() {
+			out := ORAS("manifest", "diff", RegistryRef(ZOTHost, ImageRepo, foobar.Tag), RegistryRef(ZOTHost, ImageRepo, multi_arch.Tag), "--format", "json").Exec().Out
+			var diff map[string]any
+			Expect(json.Unmarshal(out.Contents(), &diff)).ShouldNot(HaveOccurred())
+			Expect(diff["identical"]).To(BeFalse())
+			Expect(diff["mediaType"]).ShouldNot(BeNil())
+		})
+	})
+
+	When("running `manifest validate`", func() {
+		It("should validate an index", func() {
+			ORAS("manifest", "validate", RegistryRef(ZOTHost, ImageRepo, multi_arch.Tag)).
+				MatchKeyWords("Validated", RegistryRef(ZOTHost, ImageRepo, multi_arch.Digest), "No issues found").Exec()
+		})
+
+		It("should validate a manifest with platform selection", func() {
+			ORAS("manifest", "validate", RegistryRef(ZOTHost, ImageRepo, multi_arch.Tag), "--platform", "linux/amd64").
+				MatchKeyWords("Validated", multi_arch.LinuxAMD64.Digest.String(), "No issues found").Exec()
+		})
+
+		It("should report findings of a local manifest file in JSON", func() {
+			manifestPath := WriteTempFile("manifest.json", `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[]}`)
+			out := ORAS("manifest", "validate", manifestPath, "--format", "json").ExpectFailure().
+				MatchErrKeyWords("manifest validation failed").Exec().Out
+			var result struct {
+				Valid    bool `json:"valid"`
+				Findings []struct {
+					Severity string `json:"severity"`
+					Field    string `json:"field"`
+				} `json:"findings"`
+			}
+			Expect(json.Unmarshal(out.Contents(), &result)).ShouldNot(HaveOccurred())
+			Expect(result.Valid).To(BeFalse())
+			Expect(result.Findings).To(ContainElement(HaveField("Field", "artifactType")))
+		})
 	})
 
 	When("running `manifest fetch-config`", func() {
@@ -16,6 +16,7 @@ limitations under the License.
 package command
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -26,6 +27,7 @@ import (
 	"github.com/onsi/gomega"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/test/e2e/internal/testdata/artifact/blob"
 	"oras.land/oras/test/e2e/internal/testdata/artifact/config"
 	"oras.land/oras/test/e2e/internal/testdata/artifact/empty"
@@ -129,6 +131,38 @@ var _ = Describe("ORAS beginners:", func() {
 				Exec()
 		})
 
+		It("should fail if offline without cache", func() {
+			ref := RegistryRef(ZOTHost, ArtifactRepo, foobar.Tag)
+			ORAS("pull", ref, "--offline").
+				WithWorkDir(PrepareTempFiles()).
+				ExpectFailure().
+				MatchErrKeyWords("`--offline` requires the local cache").
+				Exec()
+		})
+
+		It("should pull from the cache when offline", func() {
+			tempDir := PrepareTempFiles()
+			cacheRoot := filepath.Join(tempDir, "cache")
+			ref := RegistryRef(ZOTHost, ArtifactRepo, foobar.Tag)
+			ORAS("pull", ref, "--offline", "--cache", cacheRoot).
+				WithWorkDir(tempDir).
+				ExpectFailure().
+				MatchErrKeyWords("not found in the cache", "without `--offline`").
+				Exec()
+			ORAS("pull", ref, "--cache", cacheRoot, "-o", "online").
+				WithWorkDir(tempDir).
+				Exec()
+			ORAS("pull", ref, "--offline", "--cache", cacheRoot, "-o", "offline").
+				WithWorkDir(tempDir).
+				Exec()
+			for _, name := range foobar.ImageLayerNames {
+				gomega.Expect(filepath.Join(tempDir, "offline", name)).Should(gomega.BeAnExistingFile())
+			}
+			ORAS("manifest", "fetch", ref, "--offline", "--cache", cacheRoot).
+				WithWorkDir(tempDir).
+				Exec()
+		})
+
 		It("should fail if template format is invalid", func() {
 			tempDir := PrepareTempFiles()
 			invalidPrompt := "invalid format type"
@@ -319,6 +353,35 @@ var _ = Describe("OCI spec 1.1 registry users:", func() {
 			Expect(filepath.Join(pullRoot, multi_arch.LayerName)).Should(BeAnExistingFile())
 		})
 
+		It("should resume an interrupted download of a large file", func() {
+			tempDir := GinkgoT().TempDir()
+			ref := RegistryRef(ZOTHost, fmt.Sprintf("command/pull/%d/resume", GinkgoRandomSeed()), "v1")
+			name := "large.bin"
+			blob := bytes.Repeat([]byte("0123456789abcdef"), 512*1024)
+			Expect(os.WriteFile(filepath.Join(tempDir, name), blob, 0600)).ShouldNot(HaveOccurred())
+			ORAS("push", ref, name).WithWorkDir(tempDir).Exec()
+			var manifest ocispec.Manifest
+			Expect(json.Unmarshal(ORAS("manifest", "fetch", ref).Exec().Out.Contents(), &manifest)).ShouldNot(HaveOccurred())
+			layer := manifest.Layers[0]
+
+			// simulate a download interrupted after half of the file is written
+			pullRoot := "pulled"
+			half := len(blob) / 2
+			stateDir := filepath.Join(tempDir, pullRoot, ".oras-partial")
+			Expect(os.MkdirAll(stateDir, 0700)).ShouldNot(HaveOccurred())
+			Expect(os.WriteFile(filepath.Join(tempDir, pullRoot, name), blob[:half], 0600)).ShouldNot(HaveOccurred())
+			stateJSON, err := json.Marshal(layer)
+			Expect(err).ShouldNot(HaveOccurred())
+			statePath := filepath.Join(stateDir, layer.Digest.Algorithm().String()+"-"+layer.Digest.Encoded()+".json")
+			Expect(os.WriteFile(statePath, stateJSON, 0600)).ShouldNot(HaveOccurred())
+
+			ORAS("pull", "-d", ref, "-o", pullRoot).
+				MatchErrKeyWords(fmt.Sprintf(`"Range": "bytes=%d-`, half)).
+				WithWorkDir(tempDir).Exec()
+			Binary("diff", name, filepath.Join(pullRoot, name)).WithWorkDir(tempDir).Exec()
+			Expect(stateDir).ShouldNot(BeAnExistingFile())
+		})
+
 		It("should copy an artifact with blob", func() {
 			repo := cpTestRepo("artifact-with-blob")
 			stateKeys := append(append(foobar.ImageLayerStateKeys, foobar.ManifestStateKey, foobar.ImageReferrerConfigStateKeys[0]), foobar.ImageReferrersStateKeys...)
@@ -431,6 +494,130 @@ var _ = Describe("OCI image layout users:", func() {
 				WithWorkDir(root).Exec()
 		})
 
+		It("should only pull files matching the filters", func() {
+			pullRoot := "pulled"
+			root := PrepareTempOCI(ImageRepo)
+			ORAS("pull", Flags.Layout, LayoutRef(root, foobar.Tag), "--include", "foo*", "--exclude", "foo2", "-o", pullRoot).
+				MatchKeyWords("Skipped", "foo2", "bar", "Skipped 2 file(s)").
+				WithWorkDir(root).Exec()
+			Expect(filepath.Join(root, pullRoot, "foo1")).Should(BeAnExistingFile())
+			Expect(filepath.Join(root, pullRoot, "foo2")).ShouldNot(BeAnExistingFile())
+			Expect(filepath.Join(root, pullRoot, "bar")).ShouldNot(BeAnExistingFile())
+
+			jsonRoot := "json"
+			ORAS("pull", Flags.Layout, LayoutRef(root, foobar.Tag), "--media-type", "???", "-o", jsonRoot, "--format", "json").
+				MatchKeyWords("skippedFiles", "foo1", "foo2", "bar").
+				WithWorkDir(root).Exec()
+			Expect(filepath.Join(root, jsonRoot, "foo1")).ShouldNot(BeAnExistingFile())
+		})
+
+		It("should skip existing files with matching digests", func() {
+			pullRoot := "pulled"
+			root := PrepareTempOCI(ImageRepo)
+			ORAS("pull", Flags.Layout, LayoutRef(root, foobar.Tag), "-o", pullRoot).
+				WithWorkDir(root).Exec()
+			changed := filepath.Join(root, pullRoot, "bar")
+			Expect(os.WriteFile(changed, []byte("changed"), 0644)).ShouldNot(HaveOccurred())
+			ORAS("pull", Flags.Layout, LayoutRef(root, foobar.Tag), "-o", pullRoot, "--skip-existing").
+				MatchKeyWords("Exists", "foo1", "foo2", "Downloaded", "bar").
+				WithWorkDir(root).Exec()
+			Binary("diff", filepath.Join(root, "foobar", "bar"), changed).Exec()
+		})
+
+		It("should pull atomically into the output directory", func() {
+			pullRoot := "pulled"
+			root := PrepareTempOCI(ImageRepo)
+			kept := filepath.Join(root, pullRoot, "kept")
+			Expect(os.MkdirAll(filepath.Dir(kept), 0755)).ShouldNot(HaveOccurred())
+			Expect(os.WriteFile(kept, []byte("kept"), 0644)).ShouldNot(HaveOccurred())
+			ORAS("pull", Flags.Layout, LayoutRef(root, foobar.Tag), "-o", pullRoot, "--atomic").
+				WithWorkDir(root).Exec()
+			for _, f := range foobar.ImageLayerNames {
+				Binary("diff", filepath.Join(root, "foobar", f), filepath.Join(root, pullRoot, f)).Exec()
+			}
+			Expect(kept).Should(BeAnExistingFile())
+			entries, err := os.ReadDir(root)
+			Expect(err).ShouldNot(HaveOccurred())
+			for _, entry := range entries {
+				Expect(entry.Name()).ShouldNot(ContainSubstring(".staging-"))
+			}
+		})
+
+		It("should replace the output directory", func() {
+			pullRoot := "pulled"
+			root := PrepareTempOCI(ImageRepo)
+			stale := filepath.Join(root, pullRoot, "stale")
+			Expect(os.MkdirAll(filepath.Dir(stale), 0755)).ShouldNot(HaveOccurred())
+			Expect(os.WriteFile(stale, []byte("stale"), 0644)).ShouldNot(HaveOccurred())
+			ORAS("pull", Flags.Layout, LayoutRef(root, foobar.Tag), "-o", pullRoot, "--replace-dir").
+				WithWorkDir(root).Exec()
+			for _, f := range foobar.ImageLayerNames {
+				Binary("diff", filepath.Join(root, "foobar", f), filepath.Join(root, pullRoot, f)).Exec()
+			}
+			Expect(stale).ShouldNot(BeAnExistingFile())
+		})
+
+		It("should fail to replace the working directory", func() {
+			root := PrepareTempOCI(ImageRepo)
+			ORAS("pull", Flags.Layout, LayoutRef(root, foobar.Tag), "-o", ".", "--replace-dir").
+				ExpectFailure().
+				MatchErrKeyWords("current working directory").
+				WithWorkDir(root).Exec()
+			ORAS("pull", Flags.Layout, LayoutRef(root, foobar.Tag), "--replace-dir").
+				ExpectFailure().
+				MatchErrKeyWords("--output").
+				WithWorkDir(root).Exec()
+			Expect(filepath.Join(root, "index.json")).Should(BeAnExistingFile())
+		})
+
+		It("should pull all platforms into per-platform directories", func() {
+			pullRoot := "pulled"
+			root := PrepareTempOCI(ImageRepo)
+			ORAS("pull", Flags.Layout, LayoutRef(root, multi_arch.Tag), "--all-platforms", "-o", pullRoot).
+				WithWorkDir(root).Exec()
+			for _, dir := range []string{"linux_amd64", "linux_arm64", "linux_arm_v7"} {
+				Expect(filepath.Join(root, pullRoot, dir, multi_arch.LayerName)).Should(BeAnExistingFile())
+			}
+
+			jsonRoot := "json"
+			ORAS("pull", Flags.Layout, LayoutRef(root, multi_arch.Tag), "--all-platforms", "--platform-path", "{{.Architecture}}", "-o", jsonRoot, "--format", "json").
+				MatchKeyWords("platforms", `"architecture": "arm64"`, filepath.Join(jsonRoot, "arm64", multi_arch.LayerName)).
+				WithWorkDir(root).Exec()
+		})
+
+		It("should pull referrers of the artifact type", func() {
+			root := PrepareTempOCI(ArtifactRepo)
+			ORAS("pull", Flags.Layout, LayoutRef(root, foobar.Tag), "--include-referrers", "--referrer-type", foobar.SBOMImageReferrer.ArtifactType, "--latest-referrer", "--format", "json").
+				MatchKeyWords("referrers", foobar.SBOMImageReferrer.ArtifactType, foobar.SBOMImageReferrer.Digest.String()).
+				WithWorkDir(root).Exec()
+		})
+
+		It("should fail if referrer type is specified without including referrers", func() {
+			root := PrepareTempOCI(ArtifactRepo)
+			ORAS("pull", Flags.Layout, LayoutRef(root, foobar.Tag), "--referrer-type", foobar.SBOMImageReferrer.ArtifactType).
+				ExpectFailure().
+				MatchErrKeyWords("--include-referrers").
+				WithWorkDir(root).Exec()
+		})
+
+		It("should reassemble files pushed in chunks", func() {
+			tempDir := PrepareTempFiles()
+			pullRoot := "pulled"
+			ref := LayoutRef(tempDir, foobar.Tag)
+			ORAS("push", Flags.Layout, ref, "--chunk-size", "1", foobar.FileBarName).
+				WithWorkDir(tempDir).Exec()
+			// validate
+			fetched := ORAS("manifest", "fetch", Flags.Layout, ref).Exec().Out.Contents()
+			var manifest ocispec.Manifest
+			Expect(json.Unmarshal(fetched, &manifest)).ShouldNot(HaveOccurred())
+			Expect(manifest.Layers).Should(HaveLen(3)) // "bar" is split into 3 chunks
+			ORAS("pull", Flags.Layout, ref, "-o", pullRoot).
+				MatchKeyWords(foobar.FileBarName).
+				WithWorkDir(tempDir).Exec()
+			Binary("diff", foobar.FileBarName, filepath.Join(pullRoot, foobar.FileBarName)).
+				WithWorkDir(tempDir).Exec()
+		})
+
 		It("should pull specific platform", func() {
 			root := PrepareTempOCI(ImageRepo)
 			ORAS("pull", Flags.Layout, LayoutRef(root, multi_arch.Tag), "--platform", "linux/amd64", "-o", root).
@@ -18,8 +18,10 @@ package command
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
@@ -150,6 +152,20 @@ var _ = Describe("ORAS beginners:", func() {
 				Exec()
 		})
 
+		It("should fail if the content is read from stdin without a file name", func() {
+			ORAS("push", RegistryRef(ZOTHost, pushTestRepo("stdin-no-name"), foobar.Tag), "-").
+				ExpectFailure().
+				MatchErrKeyWords("missing the file name of the content read from stdin", "--name").
+				Exec()
+		})
+
+		It("should fail if the content is read from stdin and --password-stdin is used", func() {
+			ORAS("push", RegistryRef(ZOTHost, pushTestRepo("stdin-password"), foobar.Tag), "-", "--name", "report.json", "--password-stdin").
+				ExpectFailure().
+				MatchErrKeyWords("`-`", "`--password-stdin`", " cannot be both used").
+				Exec()
+		})
+
 		It("should fail if image spec v1.1 is used, with --config and without --artifactType", func() {
 			testRepo := pushTestRepo("v1-1/no-artifact-type")
 			subjectRef := RegistryRef(ZOTHost, testRepo, foobar.Tag)
@@ -159,6 +175,13 @@ var _ = Describe("ORAS beginners:", func() {
 				MatchErrKeyWords("missing artifact type for OCI image-spec v1.1 artifacts").
 				Exec()
 		})
+
+		It("should fail if a subject is specified for image spec v1.0", func() {
+			ORAS("push", RegistryRef(ZOTHost, pushTestRepo("v1-0/subject"), foobar.Tag), "--subject", foobar.Tag, Flags.ImageSpec, "v1.0", foobar.FileBarName).
+				ExpectFailure().
+				MatchErrKeyWords("subject cannot be set for OCI image-spec v1.0 manifests").
+				Exec()
+		})
 	})
 })
 
@@ -211,6 +234,31 @@ var _ = Describe("Remote registry users:", func() {
 			}))
 		})
 
+		It("should push content read from stdin", func() {
+			repo := pushTestRepo("stdin")
+			ref := RegistryRef(ZOTHost, repo, tag)
+			content := "foo"
+			mediaType := "application/json"
+
+			ORAS("push", ref, "-", "--stdin-media-type", mediaType, "--name", "report.json").
+				WithInput(strings.NewReader(content)).
+				MatchKeyWords("report.json").
+				Exec()
+
+			// validate
+			fetched := ORAS("manifest", "fetch", ref).Exec().Out.Contents()
+			var manifest ocispec.Manifest
+			Expect(json.Unmarshal(fetched, &manifest)).ShouldNot(HaveOccurred())
+			Expect(manifest.Layers).Should(ContainElements(ocispec.Descriptor{
+				MediaType: mediaType,
+				Digest:    digest.FromString(content),
+				Size:      int64(len(content)),
+				Annotations: map[string]string{
+					"org.opencontainers.image.title": "report.json",
+				},
+			}))
+		})
+
 		It("should fail path validation when pushing file with absolute path", func() {
 			repo := pushTestRepo("path-validation")
 			ref := RegistryRef(ZOTHost, repo, tag)
@@ -567,6 +615,26 @@ var _ = Describe("OCI image layout users:", func() {
 			Expect(manifest.Layers).Should(ContainElements(foobar.BlobBarDescriptor("application/vnd.oci.image.layer.v1.tar")))
 		})
 
+		It("should push files with permissions and restore them on pull", func() {
+			tempDir := PrepareTempFiles()
+			ref := LayoutRef(tempDir, tag)
+			Expect(os.Chmod(filepath.Join(tempDir, foobar.FileBarName), 0750|os.ModeSetuid)).ShouldNot(HaveOccurred())
+			ORAS("push", Flags.Layout, ref, foobar.FileBarName, "--preserve-permissions").
+				WithWorkDir(tempDir).Exec()
+			// validate
+			fetched := ORAS("manifest", "fetch", Flags.Layout, ref).Exec().Out.Contents()
+			var manifest ocispec.Manifest
+			Expect(json.Unmarshal(fetched, &manifest)).ShouldNot(HaveOccurred())
+			Expect(manifest.Layers).Should(HaveLen(1))
+			Expect(manifest.Layers[0].Annotations).Should(HaveKeyWithValue("io.deis.oras.content.mode", "0750"))
+			Expect(manifest.Layers[0].Annotations).Should(HaveKey("io.deis.oras.content.mtime"))
+			pullRoot := "pulled"
+			ORAS("pull", Flags.Layout, ref, "-o", pullRoot).WithWorkDir(tempDir).Exec()
+			fi, err := os.Stat(filepath.Join(tempDir, pullRoot, foobar.FileBarName))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fi.Mode()).Should(Equal(os.FileMode(0750)))
+		})
+
 		It("should push files and tag", func() {
 			tempDir := PrepareTempFiles()
 			ref := LayoutRef(tempDir, tag)
@@ -587,6 +655,66 @@ var _ = Describe("OCI image layout users:", func() {
 			Expect(manifest.Layers).Should(ContainElements(foobar.BlobBarDescriptor("application/vnd.oci.image.layer.v1.tar")))
 		})
 
+		It("should push files as a tagged referrer of the subject", func() {
+			tempDir := PrepareTempFiles()
+			subjectRef := LayoutRef(tempDir, tag)
+			ORAS("push", Flags.Layout, subjectRef, foobar.FileBarName).WithWorkDir(tempDir).Exec()
+			subjectDigest := strings.TrimSpace(string(ORAS("resolve", Flags.Layout, subjectRef).Exec().Out.Contents()))
+			artifactType := "test/push.subject"
+			exportPath := "packed.json"
+			ref := LayoutRef(tempDir, "referrer")
+
+			ORAS("push", Flags.Layout, ref+",referrer2", "--subject", tag, "--artifact-type", artifactType, "--config", foobar.FileConfigName, foobar.FileBarName, "--export-manifest", exportPath).
+				MatchKeyWords("Tagged referrer2").
+				WithWorkDir(tempDir).Exec()
+
+			// validate
+			fetched := ORAS("manifest", "fetch", Flags.Layout, LayoutRef(tempDir, "referrer2")).Exec().Out.Contents()
+			MatchFile(filepath.Join(tempDir, exportPath), string(fetched), DefaultTimeout)
+			var manifest ocispec.Manifest
+			Expect(json.Unmarshal(fetched, &manifest)).ShouldNot(HaveOccurred())
+			Expect(manifest.ArtifactType).Should(Equal(artifactType))
+			Expect(manifest.Subject).ShouldNot(BeNil())
+			Expect(manifest.Subject.Digest.String()).Should(Equal(subjectDigest))
+			ORAS("discover", Flags.Layout, subjectRef, "--format", "json").
+				MatchKeyWords(artifactType).
+				Exec()
+		})
+
+		It("should push files with detected media types", func() {
+			tempDir := PrepareTempFiles()
+			ref := LayoutRef(tempDir, tag)
+			sbomName := "sbom.json"
+			Expect(os.WriteFile(filepath.Join(tempDir, sbomName), []byte(`{"spdxVersion": "SPDX-2.3"}`), 0644)).ShouldNot(HaveOccurred())
+			ORAS("push", Flags.Layout, ref, "--detect-media-type", sbomName, foobar.FileBarName).
+				MatchKeyWords("Detected", sbomName, "application/spdx+json").
+				WithWorkDir(tempDir).Exec()
+			// validate
+			fetched := ORAS("manifest", "fetch", Flags.Layout, ref).Exec().Out.Contents()
+			var manifest ocispec.Manifest
+			Expect(json.Unmarshal(fetched, &manifest)).ShouldNot(HaveOccurred())
+			Expect(manifest.Layers).Should(HaveLen(2))
+			Expect(manifest.Layers[0].MediaType).Should(Equal("application/spdx+json"))
+			Expect(manifest.Layers[1]).Should(Equal(foobar.BlobBarDescriptor("application/vnd.oci.image.layer.v1.tar")))
+		})
+
+		It("should push a directory without excluded files", func() {
+			tempDir := PrepareTempFiles()
+			ref := LayoutRef(tempDir, tag)
+			dirName := "dist"
+			Expect(os.MkdirAll(filepath.Join(tempDir, dirName), 0755)).ShouldNot(HaveOccurred())
+			Expect(os.WriteFile(filepath.Join(tempDir, dirName, "app"), []byte("app"), 0644)).ShouldNot(HaveOccurred())
+			Expect(os.WriteFile(filepath.Join(tempDir, dirName, "app.pdb"), []byte("pdb"), 0644)).ShouldNot(HaveOccurred())
+			ORAS("push", Flags.Layout, ref, "--exclude", "*.pdb", dirName).
+				MatchKeyWords("Excluded", dirName+"/app.pdb").
+				WithWorkDir(tempDir).Exec()
+			// validate
+			pullRoot := GinkgoT().TempDir()
+			ORAS("pull", Flags.Layout, ref, "-o", pullRoot).Exec()
+			Expect(filepath.Join(pullRoot, dirName, "app")).Should(BeAnExistingFile())
+			Expect(filepath.Join(pullRoot, dirName, "app.pdb")).ShouldNot(BeAnExistingFile())
+		})
+
 		It("should push files with customized media types", func() {
 			layerType := "layer.type"
 			tempDir := PrepareTempFiles()
@@ -0,0 +1,111 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"oras.land/oras/test/e2e/internal/testdata/foobar"
+	"oras.land/oras/test/e2e/internal/testdata/multi_arch"
+	. "oras.land/oras/test/e2e/internal/utils"
+)
+
+// prepareKeyPair writes a new ed25519 key pair into dir and returns the paths
+// of the private and public keys.
+func prepareKeyPair(dir string) (string, string) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	Expect(err).ShouldNot(HaveOccurred())
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	Expect(err).ShouldNot(HaveOccurred())
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	Expect(err).ShouldNot(HaveOccurred())
+	privPath := filepath.Join(dir, "key.pem")
+	pubPath := filepath.Join(dir, "pub.pem")
+	Expect(os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600)).ShouldNot(HaveOccurred())
+	Expect(os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0600)).ShouldNot(HaveOccurred())
+	return privPath, pubPath
+}
+
+var _ = Describe("ORAS beginners:", func() {
+	When("running sign and verify commands", func() {
+		It("should fail if no key is provided", func() {
+			ORAS("sign", RegistryRef(ZOTHost, ImageRepo, foobar.Tag)).
+				ExpectFailure().
+				MatchErrKeyWords("required flag", "key").
+				Exec()
+			ORAS("verify", RegistryRef(ZOTHost, ImageRepo, foobar.Tag)).
+				ExpectFailure().
+				MatchErrKeyWords("required flag", "key").
+				Exec()
+		})
+	})
+})
+
+var _ = Describe("OCI image layout users:", func() {
+	When("running sign and verify commands", func() {
+		It("should sign and verify an artifact", func() {
+			root := PrepareTempOCI(ImageRepo)
+			privPath, pubPath := prepareKeyPair(GinkgoT().TempDir())
+			subjectRef := LayoutRef(root, foobar.Tag)
+			ORAS("sign", Flags.Layout, "--key", privPath, subjectRef).
+				MatchKeyWords("Attached to", foobar.Digest).
+				Exec()
+			ORAS("verify", Flags.Layout, "--key", pubPath, subjectRef).
+				MatchKeyWords("Valid", "Verified", foobar.Digest).
+				Exec()
+			ORAS("discover", Flags.Layout, subjectRef).
+				MatchKeyWords("application/vnd.oras.signature.v1").
+				Exec()
+			ORAS("verify", Flags.Layout, "--key", pubPath, "--format", "json", subjectRef).
+				MatchKeyWords(`"verified": true`, `"valid": true`, foobar.Digest).
+				Exec()
+		})
+
+		It("should sign and verify an arch-specific artifact", func() {
+			root := PrepareTempOCI(ImageRepo)
+			privPath, pubPath := prepareKeyPair(GinkgoT().TempDir())
+			subjectRef := LayoutRef(root, multi_arch.Tag)
+			ORAS("sign", Flags.Layout, "--key", privPath, "--platform", "linux/amd64", subjectRef).Exec()
+			ORAS("verify", Flags.Layout, "--key", pubPath, LayoutRef(root, multi_arch.LinuxAMD64.Digest.String())).
+				MatchKeyWords("Verified").
+				Exec()
+			ORAS("verify", Flags.Layout, "--key", pubPath, subjectRef).
+				ExpectFailure().
+				MatchErrKeyWords("no valid signature found").
+				Exec()
+		})
+
+		It("should fail to verify with another key", func() {
+			root := PrepareTempOCI(ImageRepo)
+			privPath, _ := prepareKeyPair(GinkgoT().TempDir())
+			_, anotherPubPath := prepareKeyPair(GinkgoT().TempDir())
+			subjectRef := LayoutRef(root, foobar.Tag)
+			ORAS("sign", Flags.Layout, "--key", privPath, subjectRef).Exec()
+			ORAS("verify", Flags.Layout, "--key", anotherPubPath, subjectRef).
+				ExpectFailure().
+				MatchKeyWords("Invalid", "signed by another key").
+				MatchErrKeyWords("no valid signature found").
+				Exec()
+		})
+	})
+})